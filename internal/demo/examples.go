@@ -35,6 +35,7 @@ func main() {
 	}
 	if r := parse.Parse(fooParse, "bar"); r.FailureQ() {
 		fmt.Println("Ruh roh! Parse failure.")
+		fmt.Println(r.GetFailure()) // prints `line 1, column 1: expected "foo"`
 	}
 
 	baaarParse := parse.Regexp("ba+r")
@@ -100,4 +101,20 @@ func main() {
 		v, _ := r.GetSuccess()
 		fmt.Printf("v = %v\n", v) // prints `v = [1 23 456]`
 	}
+
+	var sub data.Lazy[parse.Parser[int]]
+	sub = data.MkLazy(func() parse.Parser[int] {
+		diff := parse.Proc(
+			parse.Seq(parse.SeqLeft(parse.Cache(sub), parse.Txt("-")), num),
+			func(p data.Pair[int, int]) int {
+				return p.First() - p.Second()
+			},
+		)
+
+		return parse.Alt(diff, num)
+	})
+	if r := parse.Parse(parse.Cache(sub), "10-2-3"); r.SuccessQ() {
+		v, _ := r.GetSuccess()
+		fmt.Printf("v = %d\n", v) // prints `v = 5`
+	}
 }
@@ -18,6 +18,7 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -33,71 +34,93 @@ import (
 // to an AST and then interpret *that*, but I've woven the
 // interpreter into the parser for brevity.
 //
-//      n ::= <number>
-//      f ::= "(" a ")" | n
-//      m ::= f "*" m | f
-//      a ::= m "+" a | m
+// The operators, from the tightest-binding to the loosest,
+// are:
+//
+//      ^       (right-associative)
+//      -       (negation)
+//      * / %   (left-associative)
+//      + -     (left-associative)
 
 var parser = func() parse.Parser[int] {
-	numP := parse.Proc(
+	numP := lexeme(parse.Proc(
 		parseext.StringOf(parseext.DigitC),
 		func(s string) int {
 			i, _ := strconv.Atoi(s)
 
 			return i
-		})
+		}))
 
-	var factor parse.Parser[int]
-
-	var mulP data.Lazy[parse.Parser[int]]
-
-	mulP = data.MkLazy(func() parse.Parser[int] {
-		mul := parse.Proc(
-			parseext.SeqS(
-				parseext.SeqLeftS(
-					factor,
-					parse.Txt("*"),
-				),
-				parse.Cache(mulP),
-			),
-			func(p data.Pair[int, int]) int {
-				return p.First() * p.Second()
-			},
-		)
+	binOp := func(op string, f func(int, int) int) parse.Parser[func(int, int) int] {
+		return parse.Proc(lexeme(parse.Txt(op)), func(string) func(int, int) int { return f })
+	}
 
-		return parse.Alt(mul, factor)
+	neg := parse.Proc(lexeme(parse.Txt("-")), func(string) func(int) int {
+		return func(x int) int { return -x }
 	})
 
-	var addP data.Lazy[parse.Parser[int]]
-
-	addP = data.MkLazy(func() parse.Parser[int] {
-		add := parse.Proc(
-			parseext.SeqS(
-				parseext.SeqLeftS(
-					parse.Cache(mulP),
-					parse.Txt("+"),
-				),
-				parse.Cache(addP),
-			),
-			func(p data.Pair[int, int]) int {
-				return p.First() + p.Second()
-			},
+	var expP data.Lazy[parse.Parser[int]]
+
+	expP = data.MkLazy(func() parse.Parser[int] {
+		factor := parse.Alt(
+			parse.SeqRight(lexeme(parse.Txt("(")), parse.SeqLeft(parse.Cache(expP), lexeme(parse.Txt(")")))),
+			numP,
 		)
 
-		return parse.Alt(add, parse.Cache(mulP))
+		return parseext.Expression(factor, [][]parseext.Operator[int]{
+			{parseext.InfixR(binOp("^", pow))},
+			{parseext.Prefix(neg)},
+			{
+				parseext.InfixL(binOp("*", func(x, y int) int { return x * y })),
+				parseext.InfixL(binOp("/", func(x, y int) int { return x / y })),
+				parseext.InfixL(binOp("%", func(x, y int) int { return x % y })),
+			},
+			{
+				parseext.InfixL(binOp("+", func(x, y int) int { return x + y })),
+				parseext.InfixL(binOp("-", func(x, y int) int { return x - y })),
+			},
+		})
 	})
 
-	factor = parse.Alt(parseext.Brackets(parse.Cache(addP)), numP)
-
-	return parseext.SeqLeftS(
-		parseext.SeqRightS(
-			parse.ParserJust(data.Unit{}),
-			parse.Cache(addP),
-		),
-		parse.Eof(),
-	)
+	return parse.SeqRight(parseext.Spaces, parse.SeqLeft(parse.Cache(expP), parse.Eof()))
 }()
 
+// Skip any spaces after `p`.
+func lexeme[A any](p parse.Parser[A]) parse.Parser[A] {
+	return parse.SeqLeft(p, parseext.Spaces)
+}
+
+func pow(x, n int) int {
+	if n < 0 {
+		panic("negative exponent")
+	}
+
+	res := 1
+	for ; n > 0; n-- {
+		res *= x
+	}
+
+	return res
+}
+
+// The interpreter panics on, e.g., division by zero.
+func eval(s string) (v int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	r := parse.Parse(parser, s)
+	if r.FailureQ() {
+		return 0, r.GetFailure()
+	}
+
+	v, _ = r.GetSuccess()
+
+	return v, nil
+}
+
 func main() {
 	for _, s := range []string{
 		"Welcome to KDP's Wonderful World o' Parsing!",
@@ -121,11 +144,17 @@ func main() {
 			return
 		}
 
-		if r := parse.Parse(parser, line); r.SuccessQ() {
-			v, _ := r.GetSuccess()
+		v, err := eval(line)
+
+		var e parse.ParseError
+
+		switch {
+		case err == nil:
 			fmt.Printf("%d\n\n", v)
-		} else {
-			fmt.Print("Parse error!\n\n")
+		case errors.As(err, &e):
+			fmt.Printf("%s^\nParse error: %s\n\n", strings.Repeat(" ", len(":> ")+e.Col-1), e)
+		default:
+			fmt.Printf("Error: %s\n\n", err)
 		}
 	}
 }
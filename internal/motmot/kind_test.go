@@ -1,6 +1,8 @@
 package main
 
 import (
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/kdpross/GoParse/pkg/parse"
@@ -26,3 +28,53 @@ func TestKind(t *testing.T) {
 		})
 	}
 }
+
+// Run with `-race`: The package-level parsers are shared by
+// all of the goroutines. (Results are checked afterwards, as
+// `assert`'s locking would hide races.)
+func TestKindConcurrent(t *testing.T) {
+	p := parse.SeqLeft(ParseKind, parse.Eof())
+
+	n := 32
+	res := make([][]string, n)
+
+	var wg sync.WaitGroup
+
+	start := make(chan struct{})
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			<-start
+
+			for j := 0; j < 50; j++ {
+				s := strings.Repeat("(*) -> ", i%7) + "*"
+
+				if v := parse.Parse(p, s); v.SuccessQ() {
+					k, _ := v.GetSuccess()
+					res[i] = append(res[i], ShowKind(k))
+				}
+
+				if parse.Parse(p, s+" ->").SuccessQ() {
+					res[i] = append(res[i], "unexpected success")
+				}
+			}
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		exp := strings.Repeat("(* -> ", i%7) + "*" + strings.Repeat(")", i%7)
+
+		assert.Len(t, res[i], 50)
+
+		for _, s := range res[i] {
+			assert.Equal(t, exp, s)
+		}
+	}
+}
@@ -30,9 +30,11 @@ var ParseKind, ParseKindS = func() (parse.Parser[Kind], parse.Parser[Kind]) {
 	var kstar, karr parse.Parser[Kind]
 
 	// Parse 'full' kinds.
-	kindP = data.MkLazy(func() parse.Parser[Kind] { return parse.Alt(karr, parse.Cache(kindPS)) })
+	kindP = data.MkLazy(func() parse.Parser[Kind] { return parse.Label(parse.Alt(karr, parse.Cache(kindPS)), "a kind") })
 	// Parse 'simple' kinds.
-	kindPS = data.MkLazy(func() parse.Parser[Kind] { return parse.Alt(kstar, bracketed(parse.Cache(kindP))) })
+	kindPS = data.MkLazy(func() parse.Parser[Kind] {
+		return parse.Label(parse.Alt(kstar, bracketed(parse.Cache(kindP))), "a kind")
+	})
 
 	kstar = parse.Proc(parse.Txt("*"), func(_ string) Kind { return KStar{} })
 	karr = parse.Proc(
@@ -46,16 +48,6 @@ var ParseKind, ParseKindS = func() (parse.Parser[Kind], parse.Parser[Kind]) {
 	return parse.Cache(kindP), parse.Cache(kindPS)
 }()
 
-func alts[A any](p parse.Parser[A], ps ...parse.Parser[A]) parse.Parser[A] {
-	res := p
-
-	for _, pP := range ps {
-		res = parse.Alt(res, pP)
-	}
-
-	return res
-}
-
 // And, here, we see how verbose doing this in Go is: If
 // we'd had the ability to override operators, all of this
 // could be written *substantially* more compactly (as,
@@ -65,61 +57,68 @@ var ParseType, ParseTypeS = func() (parse.Parser[Type], parse.Parser[Type]) {
 	var typeP, typePH, typePS data.Lazy[parse.Parser[Type]]
 	var tvar, tabs, tcval, tarr, tapp, ttpl parse.Parser[Type]
 
-	typeP = data.MkLazy(func() parse.Parser[Type] { return alts(tabs, tarr, parse.Cache(typePH)) })
-	typePH = data.MkLazy(func() parse.Parser[Type] { return alts(tcval, tapp, parse.Cache(typePS)) })
-	typePS = data.MkLazy(func() parse.Parser[Type] { return alts(tvar, ttpl) })
+	// Labelling the productions means that errors complain
+	// about a missing type rather than listing the regexps
+	// that we tried.
+	typeP = data.MkLazy(func() parse.Parser[Type] { return parse.Label(tarr, "a type") })
+	typePH = data.MkLazy(func() parse.Parser[Type] {
+		return parse.Label(parse.Choice(tcval, tapp, parse.Cache(typePS)), "a type")
+	})
+	typePS = data.MkLazy(func() parse.Parser[Type] { return parse.Label(parse.Choice(tvar, ttpl), "a type") })
 
 	varP := parse.Regexp("[a-z][a-zA-Z0-9]*")
 	consP := parse.Regexp("[A-Z][a-zA-Z0-9]*")
-	tvar = parse.Proc(varP, func(s string) Type { return TVar{s} })
-	tabs = parse.Proc(
-		parseext.SeqS1(
-			bracketed(parseext.SeqS1(varP, parseext.SeqRightS1(parse.Txt(":"), ParseKind))),
-			parseext.SeqRightS1(parse.Txt("=>"), parse.Cache(typeP)),
+	tvar = parse.ProcSpan(varP, func(s string, sp parse.Span) Type { return TVar{s, sp} })
+	tabs = parse.ProcSpan(
+		parse.Seq3(
+			parse.SeqRight(parse.Txt("("), varP),
+			parse.SeqRight(parseext.Spaces1, parseext.SeqRightS1(parse.Txt(":"), parse.SeqLeft(ParseKind, parse.Txt(")")))),
+			parse.SeqRight(parseext.Spaces1, parseext.SeqRightS1(parse.Txt("=>"), parse.Cache(typeP))),
 		),
-		func(t data.Pair[data.Pair[string, Kind], Type]) Type {
-			return TAbs{t.First().First(), t.First().Second(), t.Second()}
+		func(t data.Tuple3[string, Kind, Type], sp parse.Span) Type {
+			return TAbs{t.First(), t.Second(), t.Third(), sp}
 		},
 	)
 	tcval = func() parse.Parser[Type] {
-		withArgs := parse.Proc(parseext.SeqS1(
+		withArgs := parse.ProcSpan(parseext.SeqS1(
 			consP,
 			parseext.RepSep1(parse.Cache(typePS), parseext.Spaces1),
-		), func(p data.Pair[string, []Type]) Type { return TCVal{p.First(), p.Second()} })
-		noArgs := parse.Proc(consP, func(s string) Type { return TCVal{s, []Type{}} })
+		), func(p data.Pair[string, []Type], sp parse.Span) Type { return TCVal{p.First(), p.Second(), sp} })
+		noArgs := parse.ProcSpan(consP, func(s string, sp parse.Span) Type { return TCVal{s, []Type{}, sp} })
 		return parse.Alt(withArgs, noArgs)
 	}()
-	tarr = parse.Proc(parseext.SeqS1(
-		parse.Cache(typePH),
-		parseext.SeqRightS1(parse.Txt("->"), parse.Cache(typeP)),
-	), func(p data.Pair[Type, Type]) Type { return TArr{p.First(), p.Second()} },
+	tarr = parseext.ChainR1(
+		parse.Choice(tabs, parse.Cache(typePH)),
+		parse.Proc(
+			parse.SeqRight(parseext.Spaces1, parse.SeqLeft(parse.Txt("->"), parseext.Spaces1)),
+			func(string) func(Type, Type) Type {
+				return func(t1, t2 Type) Type { return TArr{t1, t2, spanTo(TypeSpan(t1), TypeSpan(t2))} }
+			},
+		),
 	)
-	tapp = parse.Proc(
-		parseext.RepSep1(parse.Cache(typePS), parseext.Spaces1),
-		func(ts []Type) Type {
-			var res Type
-
-			for i, t := range ts {
-				if i == 0 {
-					res = t
-				} else {
-					res = TApp{res, t}
-				}
-			}
-
-			return res
-		},
+	tapp = parseext.ChainL1(
+		parse.Cache(typePS),
+		parse.Proc(parseext.Spaces1, func(int) func(Type, Type) Type {
+			return func(t1, t2 Type) Type { return TApp{t1, t2, spanTo(TypeSpan(t1), TypeSpan(t2))} }
+		}),
 	)
-	ttpl = parse.Proc(
+	ttpl = parse.ProcSpan(
 		bracketed(parseext.RepSep1(parse.Cache(typeP), parse.Regexp(",[ ]+"))),
-		func(ts []Type) Type {
+		func(ts []Type, sp parse.Span) Type {
 			if len(ts) == 1 {
 				return ts[0]
 			} else {
-				return TTpl{ts}
+				return TTpl{ts, sp}
 			}
 		},
 	)
 
 	return parse.Cache(typeP), parse.Cache(typePS)
 }()
+
+// From the start of `sp1` to the end of `sp2`.
+func spanTo(sp1, sp2 parse.Span) parse.Span {
+	sp1.End = sp2.End
+
+	return sp1
+}
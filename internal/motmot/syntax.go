@@ -1,5 +1,7 @@
 package main
 
+import "github.com/kdpross/GoParse/pkg/parse"
+
 // ========== Kinds ==========
 
 // Transliteration of the OCaml type:
@@ -22,21 +24,38 @@ func (KArr) kindEvidence()  {}
 //          | TArr  of (typ * typ)
 //          | TApp  of (typ * typ)
 //          | TTpl  of typ list
+//
+// Each constructor also records where it came from (`Sp`),
+// for diagnostics.
 
 type Type interface{ typeEvidence() }
-type TVar struct{ X string }
+type TVar struct {
+	X  string
+	Sp parse.Span
+}
 type TAbs struct {
-	X string
-	K Kind
-	T Type
+	X  string
+	K  Kind
+	T  Type
+	Sp parse.Span
 }
 type TCVal struct {
 	C  string
 	Ts []Type
+	Sp parse.Span
+}
+type TArr struct {
+	T1, T2 Type
+	Sp     parse.Span
+}
+type TApp struct {
+	T1, T2 Type
+	Sp     parse.Span
+}
+type TTpl struct {
+	Ts []Type
+	Sp parse.Span
 }
-type TArr struct{ T1, T2 Type }
-type TApp struct{ T1, T2 Type }
-type TTpl struct{ Ts []Type }
 
 func (TVar) typeEvidence()  {}
 func (TAbs) typeEvidence()  {}
@@ -44,3 +63,24 @@ func (TCVal) typeEvidence() {}
 func (TArr) typeEvidence()  {}
 func (TApp) typeEvidence()  {}
 func (TTpl) typeEvidence()  {}
+
+func TypeSpan(t Type) parse.Span {
+	switch tT := t.(type) {
+	case TVar:
+		return tT.Sp
+	case TAbs:
+		return tT.Sp
+	case TCVal:
+		return tT.Sp
+	case TArr:
+		return tT.Sp
+	case TApp:
+		return tT.Sp
+	case TTpl:
+		return tT.Sp
+	default:
+		// Cannot happen because we've covered all `Type` ADT
+		// constructors.
+		panic("impossible")
+	}
+}
@@ -2,10 +2,12 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/kdpross/GoParse/pkg/parse"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestType(t *testing.T) {
@@ -39,3 +41,56 @@ func TestType(t *testing.T) {
 		})
 	}
 }
+
+// This would take forever if memoisation were broken.
+func TestTypeDeeplyBracketed(t *testing.T) {
+	p := parse.SeqLeft(ParseType, parse.Eof())
+
+	n := 200
+	v := parse.Parse(p, strings.Repeat("(", n)+"Foo x -> y"+strings.Repeat(")", n))
+	require.True(t, v.SuccessQ())
+	typ, _ := v.GetSuccess()
+	assert.Equal(t, "((Foo x) -> y)", ShowType(typ))
+}
+
+func TestTypeError(t *testing.T) {
+	p := parse.SeqLeft(ParseType, parse.Eof())
+
+	for _, c := range []struct {
+		lab, s string
+		col    int
+	}{
+		{"empty", "", 1},
+		{"arrow", "Foo x -> ", 10},
+		{"tuple", "(a, ", 5},
+		{"tabs", "(a : *) => ", 12},
+	} {
+		t.Run(c.lab, func(t *testing.T) {
+			v := parse.Parse(p, c.s)
+			require.True(t, v.FailureQ())
+			e := v.GetFailure()
+			assert.Equal(t, c.col, e.Col)
+			assert.Contains(t, e.Expected, "a type")
+			assert.NotContains(t, e.Expected, "[a-z][a-zA-Z0-9]*")
+		})
+	}
+}
+
+func TestTypeSpans(t *testing.T) {
+	p := parse.SeqLeft(ParseType, parse.Eof())
+
+	v := parse.Parse(p, "Foo x -> m a (b, c)")
+	require.True(t, v.SuccessQ())
+
+	typ, _ := v.GetSuccess()
+	arr, ok := typ.(TArr)
+	require.True(t, ok)
+	assert.Equal(t, parse.Span{Start: 0, End: 19, Line: 1, Col: 1}, arr.Sp)
+	assert.Equal(t, parse.Span{Start: 0, End: 5, Line: 1, Col: 1}, TypeSpan(arr.T1))
+
+	app, ok := arr.T2.(TApp)
+	require.True(t, ok)
+	assert.Equal(t, parse.Span{Start: 9, End: 19, Line: 1, Col: 10}, app.Sp)
+	assert.Equal(t, parse.Span{Start: 13, End: 19, Line: 1, Col: 14}, TypeSpan(app.T2))
+	assert.Equal(t, parse.Span{Start: 9, End: 12, Line: 1, Col: 10}, TypeSpan(app.T1))
+}
@@ -16,6 +16,8 @@
 
 package data
 
+import "sync"
+
 // Some basic data structures that somebody 'forgot to
 // implement'.
 
@@ -86,24 +88,33 @@ func (Nothing[A]) NothingQ() bool {
 	return true
 }
 
+// Copies of a lazy cell share the cell: Forcing any of
+// them forces all of them. Forcing is safe to do from
+// several goroutines at once.
 type Lazy[A any] struct {
-	avail bool
-	v     A
-	k     func() A
+	c *lazyCell[A]
+}
+
+type lazyCell[A any] struct {
+	once sync.Once
+	v    A
+	k    func() A
 }
 
 func (l *Lazy[A]) Force() A {
-	if !l.avail {
-		l.v = l.k()
-		l.avail = true
-	}
+	c := l.c
+
+	c.once.Do(func() {
+		c.v = c.k()
+	})
 
-	return l.v
+	return c.v
 }
 
 func MkLazy[A any](f func() A) Lazy[A] {
 	return Lazy[A]{
-		avail: false,
-		k:     f,
+		&lazyCell[A]{
+			k: f,
+		},
 	}
 }
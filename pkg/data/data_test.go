@@ -17,6 +17,7 @@
 package data
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -62,3 +63,66 @@ func TestLazyIdempotent(t *testing.T) {
 	_ = lz.Force()
 	assert.Equal(t, 1, callCount)
 }
+
+func TestLazyCopiesShare(t *testing.T) {
+	callCount := 0
+	lz := MkLazy(func() int {
+		callCount++
+
+		return 5
+	})
+	lzP := lz
+
+	assert.Equal(t, 5, lzP.Force())
+	assert.Equal(t, 5, lz.Force())
+	assert.Equal(t, 1, callCount)
+}
+
+func TestLazyConcurrent(t *testing.T) {
+	lz := MkLazy(func() []int { return []int{5} })
+
+	n := 16
+	res := make([]int, n)
+
+	var wg sync.WaitGroup
+
+	start := make(chan struct{})
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			<-start
+
+			res[i] = lz.Force()[0]
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+
+	for _, v := range res {
+		assert.Equal(t, 5, v)
+	}
+}
+
+func TestTupleCoherent(t *testing.T) {
+	t3 := MkTuple3(1, "two", 3.0)
+
+	assert.Equal(t, 1, t3.First())
+	assert.Equal(t, "two", t3.Second())
+	assert.Equal(t, 3.0, t3.Third())
+
+	t8 := MkTuple8(1, "two", 3.0, '4', byte(5), []int{6}, true, Unit{})
+
+	assert.Equal(t, 1, t8.First())
+	assert.Equal(t, "two", t8.Second())
+	assert.Equal(t, 3.0, t8.Third())
+	assert.Equal(t, '4', t8.Fourth())
+	assert.Equal(t, byte(5), t8.Fifth())
+	assert.Equal(t, []int{6}, t8.Sixth())
+	assert.True(t, t8.Seventh())
+	assert.Equal(t, Unit{}, t8.Eighth())
+}
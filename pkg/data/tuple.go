@@ -0,0 +1,227 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package data
+
+// Tuples of more than two components, for `parse.Seq3` and
+// friends; the accessors follow `Pair`'s.
+
+type Tuple3[A, B, C any] struct {
+	a A
+	b B
+	c C
+}
+
+func MkTuple3[A, B, C any](a A, b B, c C) Tuple3[A, B, C] {
+	return Tuple3[A, B, C]{a, b, c}
+}
+
+func (t Tuple3[A, B, C]) First() A {
+	return t.a
+}
+
+func (t Tuple3[A, B, C]) Second() B {
+	return t.b
+}
+
+func (t Tuple3[A, B, C]) Third() C {
+	return t.c
+}
+
+type Tuple4[A, B, C, D any] struct {
+	a A
+	b B
+	c C
+	d D
+}
+
+func MkTuple4[A, B, C, D any](a A, b B, c C, d D) Tuple4[A, B, C, D] {
+	return Tuple4[A, B, C, D]{a, b, c, d}
+}
+
+func (t Tuple4[A, B, C, D]) First() A {
+	return t.a
+}
+
+func (t Tuple4[A, B, C, D]) Second() B {
+	return t.b
+}
+
+func (t Tuple4[A, B, C, D]) Third() C {
+	return t.c
+}
+
+func (t Tuple4[A, B, C, D]) Fourth() D {
+	return t.d
+}
+
+type Tuple5[A, B, C, D, E any] struct {
+	a A
+	b B
+	c C
+	d D
+	e E
+}
+
+func MkTuple5[A, B, C, D, E any](a A, b B, c C, d D, e E) Tuple5[A, B, C, D, E] {
+	return Tuple5[A, B, C, D, E]{a, b, c, d, e}
+}
+
+func (t Tuple5[A, B, C, D, E]) First() A {
+	return t.a
+}
+
+func (t Tuple5[A, B, C, D, E]) Second() B {
+	return t.b
+}
+
+func (t Tuple5[A, B, C, D, E]) Third() C {
+	return t.c
+}
+
+func (t Tuple5[A, B, C, D, E]) Fourth() D {
+	return t.d
+}
+
+func (t Tuple5[A, B, C, D, E]) Fifth() E {
+	return t.e
+}
+
+type Tuple6[A, B, C, D, E, F any] struct {
+	a A
+	b B
+	c C
+	d D
+	e E
+	f F
+}
+
+func MkTuple6[A, B, C, D, E, F any](a A, b B, c C, d D, e E, f F) Tuple6[A, B, C, D, E, F] {
+	return Tuple6[A, B, C, D, E, F]{a, b, c, d, e, f}
+}
+
+func (t Tuple6[A, B, C, D, E, F]) First() A {
+	return t.a
+}
+
+func (t Tuple6[A, B, C, D, E, F]) Second() B {
+	return t.b
+}
+
+func (t Tuple6[A, B, C, D, E, F]) Third() C {
+	return t.c
+}
+
+func (t Tuple6[A, B, C, D, E, F]) Fourth() D {
+	return t.d
+}
+
+func (t Tuple6[A, B, C, D, E, F]) Fifth() E {
+	return t.e
+}
+
+func (t Tuple6[A, B, C, D, E, F]) Sixth() F {
+	return t.f
+}
+
+type Tuple7[A, B, C, D, E, F, G any] struct {
+	a A
+	b B
+	c C
+	d D
+	e E
+	f F
+	g G
+}
+
+func MkTuple7[A, B, C, D, E, F, G any](a A, b B, c C, d D, e E, f F, g G) Tuple7[A, B, C, D, E, F, G] {
+	return Tuple7[A, B, C, D, E, F, G]{a, b, c, d, e, f, g}
+}
+
+func (t Tuple7[A, B, C, D, E, F, G]) First() A {
+	return t.a
+}
+
+func (t Tuple7[A, B, C, D, E, F, G]) Second() B {
+	return t.b
+}
+
+func (t Tuple7[A, B, C, D, E, F, G]) Third() C {
+	return t.c
+}
+
+func (t Tuple7[A, B, C, D, E, F, G]) Fourth() D {
+	return t.d
+}
+
+func (t Tuple7[A, B, C, D, E, F, G]) Fifth() E {
+	return t.e
+}
+
+func (t Tuple7[A, B, C, D, E, F, G]) Sixth() F {
+	return t.f
+}
+
+func (t Tuple7[A, B, C, D, E, F, G]) Seventh() G {
+	return t.g
+}
+
+type Tuple8[A, B, C, D, E, F, G, H any] struct {
+	a A
+	b B
+	c C
+	d D
+	e E
+	f F
+	g G
+	h H
+}
+
+func MkTuple8[A, B, C, D, E, F, G, H any](a A, b B, c C, d D, e E, f F, g G, h H) Tuple8[A, B, C, D, E, F, G, H] {
+	return Tuple8[A, B, C, D, E, F, G, H]{a, b, c, d, e, f, g, h}
+}
+
+func (t Tuple8[A, B, C, D, E, F, G, H]) First() A {
+	return t.a
+}
+
+func (t Tuple8[A, B, C, D, E, F, G, H]) Second() B {
+	return t.b
+}
+
+func (t Tuple8[A, B, C, D, E, F, G, H]) Third() C {
+	return t.c
+}
+
+func (t Tuple8[A, B, C, D, E, F, G, H]) Fourth() D {
+	return t.d
+}
+
+func (t Tuple8[A, B, C, D, E, F, G, H]) Fifth() E {
+	return t.e
+}
+
+func (t Tuple8[A, B, C, D, E, F, G, H]) Sixth() F {
+	return t.f
+}
+
+func (t Tuple8[A, B, C, D, E, F, G, H]) Seventh() G {
+	return t.g
+}
+
+func (t Tuple8[A, B, C, D, E, F, G, H]) Eighth() H {
+	return t.h
+}
@@ -0,0 +1,146 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import "slices"
+
+// What a parser might start with: If `p.first` isn't nil,
+// then `p` only ever succeeds having consumed a byte in
+// `bytes` to start with, and, if it fails on anything else,
+// it does so right away, having merely recorded what it
+// expected (which `fail` does in its stead). Where we don't
+// know (e.g., for `Regexp` or `Cache`), or `p` might succeed
+// without consuming anything, it's nil.
+type firstSet struct {
+	bytes [256]bool
+	fail  func(src *source, ix int)
+}
+
+func withFirst[A any](p Parser[A], f *firstSet) Parser[A] {
+	p.first = f
+
+	return p
+}
+
+// Either of `f1` or `f2`, as for `Alt`.
+func firstUnion(f1, f2 *firstSet) *firstSet {
+	if f1 == nil || f2 == nil {
+		return nil
+	}
+
+	f := &firstSet{
+		bytes: f1.bytes,
+		fail: func(src *source, ix int) {
+			f1.fail(src, ix)
+			f2.fail(src, ix)
+		},
+	}
+
+	for b, ok := range f2.bytes {
+		f.bytes[b] = f.bytes[b] || ok
+	}
+
+	return f
+}
+
+// `Alt` for any number of parsers (and none fails), which
+// behaves exactly as the `Alt`s would (including its error
+// messages), but which, rather than trying each alternative
+// in turn, goes straight to those that might match the next
+// byte (so far as we know: see `firstSet`). So, e.g., a big
+// alternation of keywords or statements costs about the same
+// as the alternatives that start with the right letter.
+func Choice[A any](ps ...Parser[A]) Parser[A] {
+	ps = slices.Clone(ps)
+
+	// The alternatives to try at each byte; the last entry is
+	// for when there isn't one (at the end, or for tokens).
+	var dispatch [257][]int
+
+	first := &firstSet{fail: func(*source, int) {}}
+
+	for i, p := range ps {
+		for b := 0; b < 256; b++ {
+			if p.first == nil || p.first.bytes[b] {
+				dispatch[b] = append(dispatch[b], i)
+			}
+		}
+
+		if p.first == nil {
+			dispatch[256] = append(dispatch[256], i)
+		}
+
+		first = firstUnion(first, p.first)
+	}
+
+	return withFirst(makeParser(
+		func(src *source) M[A] {
+			return M[A]{
+				func(ix int) Result[A] {
+					k := 256
+					if b, ok := src.byteAt(ix); ok {
+						k = int(b)
+					}
+
+					cuts, errs, st := src.cuts, len(src.errs), src.state
+					next := 0
+
+					for j, i := range dispatch[k] {
+						skipped(src, ix, ps[next:i])
+						next = i + 1
+
+						src.errs, src.state = src.errs[:errs], st
+
+						// As with `Alt`, there's no need to be
+						// able to back up from the last resort.
+						last := j == len(dispatch[k])-1
+						if !last {
+							src.mark(ix)
+						}
+
+						r := ps[i].core(src).f(ix)
+
+						if !last {
+							src.unmark()
+						}
+
+						if r.SuccessQ() || src.cuts != cuts {
+							return r
+						}
+					}
+
+					skipped(src, ix, ps[next:])
+
+					return failure[A]{}
+				},
+			}
+		},
+	), first)
+}
+
+// The alternatives that we didn't bother with would have
+// failed at `ix`, which only matters if that's (at least) as
+// far as anything's got.
+func skipped[A any](src *source, ix int, ps []Parser[A]) {
+	for _, p := range ps {
+		if ix < src.errIx {
+			return
+		}
+
+		p.first.fail(src, ix)
+	}
+}
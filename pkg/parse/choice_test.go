@@ -0,0 +1,148 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+func alts[A any](ps ...Parser[A]) Parser[A] {
+	res := ps[len(ps)-1]
+
+	for i := len(ps) - 2; i >= 0; i-- {
+		res = Alt(ps[i], res)
+	}
+
+	return res
+}
+
+// Dispatching mustn't change what happens, including what
+// the errors say.
+func TestChoiceAgreesWithAlt(t *testing.T) {
+	word := func(s string) Parser[string] { return SeqLeft(Txt(s), Eow()) }
+	ps := []Parser[string]{
+		word("if"),
+		word("while"),
+		Label(word("for"), "a loop"),
+		Proc(Seq(Chr('{'), Txt("}")), func(data.Pair[byte, string]) string { return "{}" }),
+		Proc(OneOf(func(b byte) bool { return '0' <= b && b <= '9' }), func(b byte) string { return string(b) }),
+		Regexp("[A-Z]+"),
+		word("i"),
+		Proc(ChrR('λ'), func(c rune) string { return string(c) }),
+	}
+
+	for _, pre := range []Parser[string]{ParserJust(""), Regexp("x*")} {
+		for _, c := range []struct{ pC, pA Parser[string] }{
+			{Choice(ps...), alts(ps...)},
+			{Choice(Choice(ps[:3]...), Choice(ps[3:]...)), alts(ps...)},
+			{Alt(Choice(ps...), ParserJust("none")), Alt(alts(ps...), ParserJust("none"))},
+		} {
+			pC := SeqLeft(SeqRight(pre, c.pC), Eof())
+			pA := SeqLeft(SeqRight(pre, c.pA), Eof())
+
+			for _, s := range []string{"", "if", "i", "while", "whilst", "for", "fort", "{}", "{", "7", "ABC", "a", "λ", "xxif", "xxq", "xx"} {
+				rC, rA := Parse(pC, s), Parse(pA, s)
+
+				require.Equal(t, rA.SuccessQ(), rC.SuccessQ(), s)
+
+				if rA.SuccessQ() {
+					vA, _ := rA.GetSuccess()
+					vC, _ := rC.GetSuccess()
+					assert.Equal(t, vA, vC, s)
+				} else {
+					assert.Equal(t, rA.GetFailure(), rC.GetFailure(), s)
+				}
+			}
+		}
+	}
+}
+
+func TestChoiceDispatch(t *testing.T) {
+	var runs []byte
+
+	counted := func(c byte) Parser[byte] {
+		p := Chr(c)
+
+		return withFirst(makeParser(func(src *source) M[byte] {
+			runs = append(runs, c)
+
+			return p.core(src)
+		}), p.first)
+	}
+
+	p := Choice(counted('a'), counted('b'), counted('c'), counted('b'))
+
+	r := Parse(p, "b")
+
+	require.True(t, r.SuccessQ())
+	assert.Equal(t, []byte("b"), runs)
+
+	runs = nil
+	r = Parse(p, "d")
+
+	require.True(t, r.FailureQ())
+	assert.Empty(t, runs)
+	assert.Equal(t, []string{`"a"`, `"b"`, `"c"`}, r.GetFailure().Expected)
+
+	runs = nil
+	r = Parse(p, "")
+
+	require.True(t, r.FailureQ())
+	assert.Empty(t, runs)
+}
+
+func TestChoiceEmpty(t *testing.T) {
+	r := Parse(Choice[int](), "x")
+
+	require.True(t, r.FailureQ())
+	assert.Equal(t, 0, r.GetFailure().Offset)
+	assert.Empty(t, r.GetFailure().Expected)
+
+	r = Parse(Alt(Choice[int](), ParserJust(1)), "x")
+
+	require.True(t, r.SuccessQ())
+}
+
+func TestChoiceCut(t *testing.T) {
+	p := Choice(
+		SeqRight(Seq(Txt("let"), Cut()), Txt(" x")),
+		Txt("letter"),
+	)
+
+	assert.True(t, Parse(p, "let x").SuccessQ())
+
+	r := Parse(p, "letter")
+
+	require.True(t, r.FailureQ())
+	assert.Equal(t, []string{`" x"`}, r.GetFailure().Expected)
+}
+
+func TestChoiceTokens(t *testing.T) {
+	p := Choice(Txt("a"), Proc(Satisfy(func(n int) bool { return n > 0 }), func(int) string { return "pos" }))
+
+	r := ParseTokens(p, []int{1})
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, "pos", v)
+}
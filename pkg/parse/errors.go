@@ -0,0 +1,138 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// What went wrong, and where. `Offset` is a byte offset
+// into the input; `Line` and `Col` are 1-based, with `Col`
+// counted in characters (not bytes). (For `ParseTokens`,
+// `Offset` counts tokens, and `Line` and `Col` are 0.)
+// `Messages` come from `ParserFail` (and `NotFollowedBy`).
+type ParseError struct {
+	Offset   int
+	Line     int
+	Col      int
+	Expected []string
+	Messages []string
+}
+
+var _ error = ParseError{}
+
+func (e ParseError) Error() string {
+	what := slices.Clone(e.Messages)
+
+	if len(e.Expected) > 0 {
+		what = append(what, "expected "+oxfordOr(e.Expected))
+	}
+
+	if len(what) == 0 {
+		what = []string{"parse error"}
+	}
+
+	where := fmt.Sprintf("line %d, column %d", e.Line, e.Col)
+	if e.Line == 0 {
+		where = fmt.Sprintf("token %d", e.Offset)
+	}
+
+	return where + ": " + strings.Join(what, "; ")
+}
+
+func oxfordOr(ss []string) string {
+	switch len(ss) {
+	case 1:
+		return ss[0]
+	case 2:
+		return ss[0] + " or " + ss[1]
+	default:
+		return strings.Join(ss[:len(ss)-1], ", ") + ", or " + ss[len(ss)-1]
+	}
+}
+
+// Render the set of bytes accepted by `p` in the familiar
+// regexp-class notation, e.g., `[a-z]`; since we can't look
+// inside the predicate, we just ask it about every byte.
+// Returns "" for the empty class (which expects nothing).
+func describeClass(p func(byte) bool) string {
+	var in []byte
+
+	for i := 0; i < 256; i++ {
+		if p(byte(i)) {
+			in = append(in, byte(i))
+		}
+	}
+
+	switch {
+	case len(in) == 0:
+		return ""
+	case len(in) == 1:
+		return strconv.Quote(string(in))
+	case len(in) == 256:
+		return "any character"
+	case len(in) > 128:
+		// It's (much) easier to read the complement.
+		return "[^" + classRanges(func(b byte) bool { return !p(b) }) + "]"
+	default:
+		return "[" + classRanges(p) + "]"
+	}
+}
+
+func classRanges(p func(byte) bool) string {
+	var sb strings.Builder
+
+	for i := 0; i < 256; {
+		if !p(byte(i)) {
+			i++
+
+			continue
+		}
+
+		j := i
+		for j+1 < 256 && p(byte(j+1)) {
+			j++
+		}
+
+		switch {
+		case j-i >= 2:
+			sb.WriteString(classByte(byte(i)) + "-" + classByte(byte(j)))
+		case j-i == 1:
+			sb.WriteString(classByte(byte(i)) + classByte(byte(j)))
+		default:
+			sb.WriteString(classByte(byte(i)))
+		}
+
+		i = j + 1
+	}
+
+	return sb.String()
+}
+
+func classByte(b byte) string {
+	switch {
+	case strings.IndexByte(`\]^-`, b) >= 0:
+		return `\` + string(b)
+	case b < ' ' || b > '~':
+		return fmt.Sprintf(`\x%02x`, b)
+	default:
+		return string(b)
+	}
+}
@@ -0,0 +1,141 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+func TestFailurePrimitives(t *testing.T) {
+	for _, c := range []struct {
+		lab string
+		p   Parser[string]
+		s   string
+		exp []string
+	}{
+		{"txt", Txt("->"), "=>", []string{`"->"`}},
+		{"chr", Proc(Chr('x'), func(byte) string { return "" }), "y", []string{`"x"`}},
+		{"oneof", Proc(OneOf(func(b byte) bool { return b >= 'a' && b <= 'z' }), func(byte) string { return "" }), "A", []string{"[a-z]"}},
+		{"noneof", Proc(NoneOf(func(b byte) bool { return b == ' ' }), func(byte) string { return "" }), " ", []string{`[^ ]`}},
+		{"regexp", Regexp("[0-9]+"), "x", []string{"[0-9]+"}},
+		{"eof", SeqLeft(Txt("a"), Proc(Eof(), func(data.Unit) string { return "" })), "ab", []string{"end of input"}},
+		{"alt", Alt(Txt("foo"), Txt("bar")), "baz", []string{`"foo"`, `"bar"`}},
+	} {
+		t.Run(c.lab, func(t *testing.T) {
+			r := Parse(c.p, c.s)
+
+			require.True(t, r.FailureQ())
+			assert.ElementsMatch(t, c.exp, r.GetFailure().Expected)
+		})
+	}
+}
+
+func TestFailureFarthest(t *testing.T) {
+	// The first alternative gets farther, so its complaint is
+	// the one that we hear about.
+	p := Alt(
+		SeqRight(Txt("foo "), Txt("->")),
+		SeqRight(Txt("f"), Txt("x")),
+	)
+
+	r := Parse(p, "foo =>")
+
+	require.True(t, r.FailureQ())
+
+	e := r.GetFailure()
+	assert.Equal(t, 4, e.Offset)
+	assert.Equal(t, []string{`"->"`}, e.Expected)
+	assert.Equal(t, `line 1, column 5: expected "->"`, e.Error())
+}
+
+func TestFailureLineCol(t *testing.T) {
+	p := SeqRight(Regexp("[a-zé\n]*"), Eof())
+
+	r := Parse(p, "abc\nxé\naé!")
+
+	require.True(t, r.FailureQ())
+
+	e := r.GetFailure()
+	assert.Equal(t, 11, e.Offset)
+	assert.Equal(t, 3, e.Line)
+	assert.Equal(t, 3, e.Col)
+	assert.Equal(t, "line 3, column 3: expected end of input", e.Error())
+}
+
+func TestDescribeClass(t *testing.T) {
+	for _, c := range []struct {
+		exp string
+		p   func(byte) bool
+	}{
+		{"", func(byte) bool { return false }},
+		{`"Q"`, func(b byte) bool { return b == 'Q' }},
+		{"[ab]", func(b byte) bool { return b == 'a' || b == 'b' }},
+		{"[0-9A-Z_]", func(b byte) bool { return b >= '0' && b <= '9' || b >= 'A' && b <= 'Z' || b == '_' }},
+		{`[\-\]]`, func(b byte) bool { return b == '-' || b == ']' }},
+		{`[^\x00\x0a]`, func(b byte) bool { return b != 0 && b != '\n' }},
+		{"any character", func(byte) bool { return true }},
+	} {
+		assert.Equal(t, c.exp, describeClass(c.p))
+	}
+}
+
+func TestFailureMessage(t *testing.T) {
+	p := Alt(Txt("foo"), ParserFail[string]("no foo here"))
+
+	r := Parse(p, "bar")
+
+	require.True(t, r.FailureQ())
+
+	e := r.GetFailure()
+	assert.Equal(t, []string{"no foo here"}, e.Messages)
+	assert.Equal(t, `line 1, column 1: no foo here; expected "foo"`, e.Error())
+}
+
+func TestLabel(t *testing.T) {
+	num := Label(Regexp("[0-9]+"), "a number")
+
+	t.Run("no progress", func(t *testing.T) {
+		r := Parse(SeqRight(Txt("x = "), num), "x = y")
+
+		require.True(t, r.FailureQ())
+		assert.Equal(t, []string{"a number"}, r.GetFailure().Expected)
+	})
+
+	t.Run("keeps unrelated expectations", func(t *testing.T) {
+		r := Parse(Alt(num, Txt("y")), "z")
+
+		require.True(t, r.FailureQ())
+		assert.ElementsMatch(t, []string{"a number", `"y"`}, r.GetFailure().Expected)
+	})
+
+	t.Run("progress", func(t *testing.T) {
+		pair := Label(SeqRight(Txt("("), SeqLeft(num, Txt(")"))), "a pair")
+
+		r := Parse(pair, "(12]")
+
+		require.True(t, r.FailureQ())
+
+		e := r.GetFailure()
+		assert.Equal(t, 3, e.Offset)
+		assert.Equal(t, []string{`")"`}, e.Expected)
+	})
+}
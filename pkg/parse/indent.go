@@ -0,0 +1,74 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+// For layout-sensitive ('offside rule') languages, such as
+// Python or YAML: A parse keeps track of a reference column
+// (the one that a block's lines start in, say), which is
+// column 1 to begin with. `parseext` has the block-building
+// combinators; these are the primitives. Like the user's
+// state, the reference indent is something that `Cache`d
+// results depend on, so they're only recalled for the same
+// one.
+
+// The current column (see `ParseError`), without consuming
+// anything; it's 0 for tokens.
+func Column() Parser[int] {
+	return makeParser(
+		func(src *source) M[int] {
+			return M[int]{
+				func(ix int) Result[int] {
+					_, col := src.lineCol(ix)
+
+					return success[int]{col, ix}
+				},
+			}
+		},
+	)
+}
+
+func ReferenceIndent() Parser[int] {
+	return makeParser(
+		func(src *source) M[int] {
+			return M[int]{
+				func(ix int) Result[int] {
+					return success[int]{src.indent, ix}
+				},
+			}
+		},
+	)
+}
+
+// Run `p` with `col` as the reference column; it's put back
+// afterwards (however `p` gets on).
+func WithReferenceIndent[A any](col int, p Parser[A]) Parser[A] {
+	return withFirst(makeParser(
+		func(src *source) M[A] {
+			return M[A]{
+				func(ix int) Result[A] {
+					indent := src.indent
+
+					src.indent = col
+					r := p.core(src).f(ix)
+					src.indent = indent
+
+					return r
+				},
+			}
+		},
+	), p.first)
+}
@@ -0,0 +1,68 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+func TestColumn(t *testing.T) {
+	p := Seq(SeqRight(Txt("ab\n  "), Column()), SeqRight(Txt("c"), Column()))
+
+	r := Parse(p, "ab\n  c")
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, data.MkPair(3, 4), v)
+
+	rT := ParseTokens(Column(), []int{1})
+
+	require.True(t, rT.SuccessQ())
+
+	vT, _ := rT.GetSuccess()
+	assert.Equal(t, 0, vT)
+}
+
+func TestWithReferenceIndent(t *testing.T) {
+	p := Seq(
+		ReferenceIndent(),
+		Seq(WithReferenceIndent(4, ReferenceIndent()), ReferenceIndent()),
+	)
+
+	r := Parse(p, "")
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, data.MkPair(1, data.MkPair(4, 1)), v)
+
+	// It's put back even if `p` fails.
+	q := Alt(WithReferenceIndent(4, SeqRight(Txt("x"), ReferenceIndent())), ReferenceIndent())
+
+	rQ := Parse(q, "y")
+
+	require.True(t, rQ.SuccessQ())
+
+	n, _ := rQ.GetSuccess()
+	assert.Equal(t, 1, n)
+}
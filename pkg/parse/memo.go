@@ -0,0 +1,322 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"slices"
+)
+
+// Memoisation with support for left recursion, per 'Packrat
+// Parsers Can Support Left Recursion' (A.Warth, J.Douglass,
+// T.Millstein, 2008). The names follow the paper, where a
+// 'rule' is a cached parser. The idea: When a rule finds
+// itself (via its memo entry) at the same offset, it fails
+// there; whatever it manages to parse without recursing is
+// the 'seed', which we then 'grow' by re-running the rule
+// (with the previous result in the memo table) until it
+// stops getting any farther.
+
+type memoEntry[A any] struct {
+	res Result[A]
+	// Whether working out `res` got past a `Cut`, and what
+	// it had to `Recover` from (which recalling it had
+	// better do, too).
+	cut  bool
+	errs []ParseError
+	// The user's state before and after: The entry only
+	// stands for `from` (see 'state.go'), and for `indent`
+	// (see 'indent.go').
+	from, to userState
+	indent   int
+	// Non-`nil` while the rule is still working out its
+	// result (in which case `res` is the seed so far).
+	lr *lrFrame
+}
+
+// One per rule invocation in progress.
+type lrFrame struct {
+	rule uint64
+	head *lrHead
+	next *lrFrame
+}
+
+// The rule at which a left-recursive cycle starts, along with
+// the other rules in the cycle: `involved` are those that
+// may be re-evaluated while growing the seed; `eval` those
+// that haven't been re-evaluated yet during this iteration;
+// `done` marks the other rules' memo entries as finished,
+// once the head is.
+type lrHead struct {
+	rule     uint64
+	involved map[uint64]bool
+	eval     map[uint64]bool
+	done     []func()
+}
+
+// A rule's memo entries, from offset `base` on; for
+// `ParseReader`, entries before the window are dropped (see
+// 'stream.go').
+type memoTable[A any] struct {
+	base    int
+	entries []*memoEntry[A]
+}
+
+// What the `source` needs to know about a memo table without
+// knowing its type.
+type memoWindow interface {
+	prune(ix int)
+	size() int
+}
+
+func getMemoTable[A any](src *source, p Parser[A]) *memoTable[A] {
+	if t, ok := src.memo[p.id]; ok {
+		return t.(*memoTable[A])
+	}
+
+	t := &memoTable[A]{base: src.base}
+
+	src.memo[p.id] = t
+
+	return t
+}
+
+func (t *memoTable[A]) get(ix int) *memoEntry[A] {
+	if i := ix - t.base; i >= 0 && i < len(t.entries) {
+		return t.entries[i]
+	}
+
+	return nil
+}
+
+// Nothing can be parsing before the window, but just in case,
+// entries there are quietly dropped.
+func (t *memoTable[A]) set(ix int, m *memoEntry[A]) {
+	i := ix - t.base
+	if i < 0 {
+		return
+	}
+
+	if i >= len(t.entries) {
+		t.entries = append(t.entries, make([]*memoEntry[A], i+1-len(t.entries))...)
+	}
+
+	t.entries[i] = m
+}
+
+// Copy what's left, so that the old entries can go.
+func (t *memoTable[A]) prune(ix int) {
+	k := ix - t.base
+	if k <= 0 {
+		return
+	}
+
+	if k < len(t.entries) {
+		t.entries = slices.Clone(t.entries[k:])
+	} else {
+		t.entries = nil
+	}
+
+	t.base = ix
+}
+
+func (t *memoTable[A]) size() int {
+	return len(t.entries)
+}
+
+func applyRule[A any](src *source, p Parser[A], ix int) Result[A] {
+	t := getMemoTable(src, p)
+
+	m := recall(src, p, t, ix)
+
+	if m == nil {
+		// We may need to come back here to grow a seed.
+		src.pin(ix)
+		defer src.unpin()
+
+		cuts, errs := src.cuts, len(src.errs)
+
+		lr := &lrFrame{rule: p.id, next: src.lrStack}
+		src.lrStack = lr
+
+		m = &memoEntry[A]{res: failure[A]{}, from: src.state, indent: src.indent, lr: lr}
+		t.set(ix, m)
+
+		res := p.core(src).f(ix)
+
+		src.lrStack = lr.next
+
+		m.errs, m.to = slices.Clone(src.errs[errs:]), src.state
+
+		if lr.head != nil {
+			m.res = res
+			res = lrAnswer(src, p, ix, m, errs)
+		} else {
+			m.res, m.lr = res, nil
+		}
+
+		m.cut = src.cuts != cuts
+
+		return res
+	}
+
+	// (A result that's still being worked out leaves the state
+	// alone.)
+	if m.lr != nil {
+		setupLR(src, p.id, m.lr)
+	} else {
+		src.state = m.to
+	}
+
+	if m.cut {
+		src.cuts++
+	}
+
+	src.errs = append(src.errs, m.errs...)
+
+	return m.res
+}
+
+// Every rule between the top of the stack and the one that
+// we've just found ourselves in is part of its cycle.
+func setupLR(src *source, rule uint64, lr *lrFrame) {
+	if lr.head == nil {
+		lr.head = &lrHead{rule: rule, involved: map[uint64]bool{}, eval: map[uint64]bool{}}
+	}
+
+	for s := src.lrStack; s != nil && s.head != lr.head; s = s.next {
+		s.head = lr.head
+		lr.head.involved[s.rule] = true
+	}
+}
+
+func lrAnswer[A any](src *source, p Parser[A], ix int, m *memoEntry[A], errs int) Result[A] {
+	h := m.lr.head
+
+	// Not the head of the cycle: Leave the growing to the
+	// rule that is.
+	if h.rule != p.id {
+		h.done = append(h.done, func() { m.lr = nil })
+
+		return m.res
+	}
+
+	// Whether or not the seed grows, the other rules' entries
+	// are as done as they're going to get (and mustn't send a
+	// later call looking for a cycle that's gone).
+	defer func() {
+		for _, f := range h.done {
+			f()
+		}
+	}()
+
+	m.lr = nil
+
+	if m.res.FailureQ() {
+		return m.res
+	}
+
+	return growLR(src, p, ix, m, h, errs)
+}
+
+// Each time round, we start over from `ix` (with the previous
+// result in the memo table, along with its errors), so only
+// the errors from the last round that got anywhere count;
+// the `src.errs` before `errs` aren't ours.
+func growLR[A any](src *source, p Parser[A], ix int, m *memoEntry[A], h *lrHead, errs int) Result[A] {
+	src.heads[ix] = h
+
+	for {
+		h.eval = map[uint64]bool{}
+		for r := range h.involved {
+			h.eval[r] = true
+		}
+
+		cuts := src.cuts
+		src.errs, src.state = src.errs[:errs], m.from
+
+		res := p.core(src).f(ix)
+
+		// A failure after a cut is an error, not just the end
+		// of the growing.
+		if res.FailureQ() && src.cuts != cuts {
+			m.res = res
+
+			break
+		}
+
+		if res.FailureQ() {
+			src.errs, src.state = append(src.errs[:errs], m.errs...), m.to
+
+			break
+		}
+
+		_, ixNew := res.GetSuccess()
+		_, ixOld := m.res.GetSuccess()
+
+		if ixNew <= ixOld {
+			src.errs, src.state = append(src.errs[:errs], m.errs...), m.to
+
+			break
+		}
+
+		m.res, m.errs, m.to = res, slices.Clone(src.errs[errs:]), src.state
+	}
+
+	delete(src.heads, ix)
+
+	return m.res
+}
+
+// While a seed is growing at `ix`, the rules involved in the
+// cycle must be re-evaluated (once per iteration) rather
+// than answered from the memo table, and rules *not*
+// involved mustn't get a look in.
+func recall[A any](src *source, p Parser[A], t *memoTable[A], ix int) *memoEntry[A] {
+	m := t.get(ix)
+	h := src.heads[ix]
+
+	// Nor is an entry from another state (or reference indent)
+	// any use, unless it's still being worked out.
+	if m != nil && m.lr == nil && (m.from.gen != src.state.gen || m.indent != src.indent) {
+		m = nil
+	}
+
+	if h == nil {
+		return m
+	}
+
+	if m == nil && p.id != h.rule && !h.involved[p.id] {
+		return &memoEntry[A]{res: failure[A]{}, from: src.state, to: src.state, indent: src.indent}
+	}
+
+	if h.eval[p.id] {
+		delete(h.eval, p.id)
+
+		if m == nil {
+			m = &memoEntry[A]{}
+			t.set(ix, m)
+		}
+
+		errs := len(src.errs)
+
+		m.from, m.indent = src.state, src.indent
+		m.res, m.lr = p.core(src).f(ix), nil
+		m.errs, m.to = slices.Clone(src.errs[errs:]), src.state
+	}
+
+	return m
+}
@@ -0,0 +1,162 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+var numP = Regexp("[0-9]+")
+
+func binOp(op string) func(data.Pair[string, string]) string {
+	return func(p data.Pair[string, string]) string {
+		return fmt.Sprintf("(%s %s %s)", p.First(), op, p.Second())
+	}
+}
+
+func parseAll[A any](t *testing.T, p Parser[A], s string) A {
+	r := Parse(SeqLeft(p, Eof()), s)
+
+	require.True(t, r.SuccessQ(), "parsing %q", s)
+
+	v, _ := r.GetSuccess()
+
+	return v
+}
+
+// expr ::= expr "-" num | num
+func TestLeftRecursionDirect(t *testing.T) {
+	var expr data.Lazy[Parser[string]]
+	expr = data.MkLazy(func() Parser[string] {
+		return Alt(
+			Proc(Seq(SeqLeft(Cache(expr), Txt("-")), numP), binOp("-")),
+			numP,
+		)
+	})
+
+	for _, c := range []struct{ s, exp string }{
+		{"1", "1"},
+		{"1-2", "(1 - 2)"},
+		{"1-2-3", "((1 - 2) - 3)"},
+		{"1-2-3-4", "(((1 - 2) - 3) - 4)"},
+	} {
+		assert.Equal(t, c.exp, parseAll(t, Cache(expr), c.s))
+	}
+
+	assert.True(t, Parse(SeqLeft(Cache(expr), Eof()), "1-").FailureQ())
+	assert.True(t, Parse(Cache(expr), "-1").FailureQ())
+}
+
+// expr ::= expr "-" term | term
+// term ::= term "*" num | num
+func TestLeftRecursionNested(t *testing.T) {
+	var expr, term data.Lazy[Parser[string]]
+	expr = data.MkLazy(func() Parser[string] {
+		return Alt(
+			Proc(Seq(SeqLeft(Cache(expr), Txt("-")), Cache(term)), binOp("-")),
+			Cache(term),
+		)
+	})
+	term = data.MkLazy(func() Parser[string] {
+		return Alt(
+			Proc(Seq(SeqLeft(Cache(term), Txt("*")), numP), binOp("*")),
+			numP,
+		)
+	})
+
+	for _, c := range []struct{ s, exp string }{
+		{"1*2", "(1 * 2)"},
+		{"1-2*3-4", "((1 - (2 * 3)) - 4)"},
+		{"1*2*3-4*5*6", "(((1 * 2) * 3) - ((4 * 5) * 6))"},
+	} {
+		assert.Equal(t, c.exp, parseAll(t, Cache(expr), c.s))
+	}
+}
+
+// x    ::= expr
+// expr ::= x "-" num | num
+func TestLeftRecursionIndirect(t *testing.T) {
+	var x, expr data.Lazy[Parser[string]]
+	x = data.MkLazy(func() Parser[string] {
+		return Cache(expr)
+	})
+	expr = data.MkLazy(func() Parser[string] {
+		return Alt(
+			Proc(Seq(SeqLeft(Cache(x), Txt("-")), numP), binOp("-")),
+			numP,
+		)
+	})
+
+	assert.Equal(t, "((1 - 2) - 3)", parseAll(t, Cache(x), "1-2-3"))
+	assert.Equal(t, "((1 - 2) - 3)", parseAll(t, Cache(expr), "1-2-3"))
+}
+
+// Java-ish member access / calls, from the Warth et al paper:
+//
+//	prim ::= prim "." id | call | id
+//	call ::= prim "(" ")"
+func TestLeftRecursionMutual(t *testing.T) {
+	id := Regexp("[a-z]+")
+
+	var prim, call data.Lazy[Parser[string]]
+	prim = data.MkLazy(func() Parser[string] {
+		return Alt(
+			Proc(Seq(SeqLeft(Cache(prim), Txt(".")), id), binOp(".")),
+			Alt(Cache(call), id),
+		)
+	})
+	call = data.MkLazy(func() Parser[string] {
+		return Proc(SeqLeft(Cache(prim), Txt("()")), func(s string) string { return s + "()" })
+	})
+
+	for _, c := range []struct{ s, exp string }{
+		{"a", "a"},
+		{"a.b", "(a . b)"},
+		{"a()", "a()"},
+		{"a.b().c", "((a . b)() . c)"},
+		{"a().b()()", "(a() . b)()()"},
+	} {
+		assert.Equal(t, c.exp, parseAll(t, Cache(prim), c.s))
+	}
+}
+
+// A cycle whose seed fails mustn't leave the other rule
+// looking for it later on.
+//
+//	x ::= y "a" | "b"
+//	y ::= x "c" | "d"
+func TestLeftRecursionFailedSeed(t *testing.T) {
+	var x, y data.Lazy[Parser[string]]
+	x = data.MkLazy(func() Parser[string] {
+		return Alt(SeqLeft(Cache(y), Txt("a")), Txt("b"))
+	})
+	y = data.MkLazy(func() Parser[string] {
+		return Alt(SeqLeft(Cache(x), Txt("c")), Txt("d"))
+	})
+
+	p := SeqLeft(Alt(Cache(x), Cache(y)), Eof())
+
+	assert.True(t, Parse(p, "bca").SuccessQ())
+	assert.True(t, Parse(p, "d").SuccessQ())
+	assert.True(t, Parse(p, "z").FailureQ())
+}
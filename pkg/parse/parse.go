@@ -19,20 +19,152 @@ package parse
 // Based on 'Packrat Parsing' (B.Ford, 2002)
 
 import (
-	"github.com/gijsbers/go-pcre"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"github.com/kdpross/GoParse/pkg/data"
 )
 
+// Everything mutable lives here, and there's one of these
+// per call to `Parse`; `Parser`s themselves are immutable,
+// so the same parser may be used by any number of
+// goroutines at once.
 type source struct {
-	str  string
-	undo []func()
+	// The input: Either text or, for `ParseTokens`, a slice
+	// of tokens (which is actually a `[]T` for some `T`) of
+	// length `n`. For `ParseReader`, `str` is just a window
+	// onto the text, starting at offset `base` and topped up
+	// from `rd`; `marks` are the offsets that we might yet
+	// back up to (and `pins` those that we need regardless
+	// of any `Cut`, e.g., to re-run a `Cache` or for
+	// `Consumed`), and `line0` and `col0` are the position of
+	// `base`; we read `chunk` bytes (or more) at a time. (See
+	// 'stream.go'.) We've looked for newlines up
+	// to `scanned`, and found them at `nls`; the last position
+	// that we worked out was `posLine` and `posCol`, at
+	// `posIx`.
+	str     string
+	toks    any
+	n       int
+	base    int
+	rd      io.Reader
+	rdErr   error
+	chunk   int
+	marks   []int
+	pins    []int
+	line0   int
+	col0    int
+	scanned int
+	nls     []int
+	posIx   int
+	posLine int
+	posCol  int
+	// How many times we've passed a `Cut`, and where we last
+	// did so.
+	cuts  int
+	cutIx int
+	// Packrat memo tables, by parser ID; each is actually a
+	// `*memoTable[A]` for the parser's `A`.
+	memo map[uint64]memoWindow
+	// Left-recursion bookkeeping (see 'memo.go').
+	lrStack *lrFrame
+	heads   map[int]*lrHead
+	// The farthest offset at which anything failed, along
+	// with what we'd have accepted there (and any complaints
+	// from `ParserFail`); this is what gets reported if the
+	// whole parse fails.
+	errIx   int
+	expect  []string
+	msgs    []string
+	errLine int
+	errCol  int
+	// Errors that `Recover` has recovered from (so far as
+	// we've not backed up past them).
+	errs []ParseError
+	// The user's state (see 'state.go'), and how many times
+	// it's been set.
+	state userState
+	gens  uint64
+	// The reference column for layout (see 'indent.go').
+	indent int
+	// High-water marks, to keep `ParseReader` honest.
+	maxWindow int
+	maxMemo   int
+}
+
+// Only the farthest failure is interesting: Anything that
+// failed closer to the start was (presumably) a wrong turn
+// taken by an `Alt`. Returns whether `ix` is (now) the
+// farthest offset.
+func (src *source) reach(ix int) bool {
+	if ix > src.errIx {
+		src.errIx = ix
+		src.expect = nil
+		src.msgs = nil
+	}
+
+	return ix == src.errIx
+}
+
+// Record that we expected `what` at `ix`. (It's fine not to
+// know what we expected: It's still worth knowing how far we
+// got.)
+func (src *source) expected(ix int, what string) {
+	if src.reach(ix) && what != "" && !slices.Contains(src.expect, what) {
+		src.expect = append(src.expect, what)
+	}
+}
+
+// Record that something at `ix` complained.
+func (src *source) message(ix int, msg string) {
+	if src.reach(ix) && !slices.Contains(src.msgs, msg) {
+		src.msgs = append(src.msgs, msg)
+	}
+}
+
+func newSource(s string) *source {
+	return &source{
+		str:    s,
+		memo:   map[uint64]memoWindow{},
+		heads:  map[int]*lrHead{},
+		indent: 1,
+		chunk:  readChunk,
+	}
 }
 
+func (src *source) parseError() ParseError {
+	line, col := src.lineCol(src.errIx)
+
+	return ParseError{
+		Offset:   src.errIx,
+		Line:     line,
+		Col:      col,
+		Expected: src.expect,
+		Messages: src.msgs,
+	}
+}
+
+// Lines and columns don't mean anything for tokens.
+func (src *source) lineCol(ix int) (int, int) {
+	if src.toks != nil {
+		return 0, 0
+	}
+
+	return src.position(ix)
+}
+
+// `GetErrors` is every error in the parse: those that
+// `Recover` got past and, on failure, the one that it
+// couldn't.
 type Result[A any] interface {
 	SuccessQ() bool
 	FailureQ() bool
 	GetSuccess() (A, int)
+	GetFailure() ParseError
+	GetErrors() []ParseError
 }
 
 type success[A any] struct {
@@ -50,11 +182,24 @@ func (s success[A]) GetSuccess() (A, int) {
 	return s.v, s.ix
 }
 
+func (success[A]) GetFailure() ParseError {
+	panic("unimplemented")
+}
+
 func (success[A]) SuccessQ() bool {
 	return true
 }
 
-type failure[A any] struct{}
+func (success[A]) GetErrors() []ParseError {
+	return nil
+}
+
+// Failures arising *during* parsing don't carry any
+// information; it's only filled in (from the `source`) once
+// `Parse` gives up.
+type failure[A any] struct {
+	err ParseError
+}
 
 var _ Result[int] = failure[int]{}
 
@@ -66,15 +211,28 @@ func (failure[A]) GetSuccess() (A, int) {
 	panic("unimplemented")
 }
 
+func (f failure[A]) GetFailure() ParseError {
+	return f.err
+}
+
 func (failure[A]) SuccessQ() bool {
 	return false
 }
 
+func (f failure[A]) GetErrors() []ParseError {
+	return []ParseError{f.err}
+}
+
 type Parser[A any] struct {
-	core  func(src source) M[A]
-	cache []data.Maybe[Result[A]]
+	core func(src *source) M[A]
+	// Identifies the parser's memo table (see `Cache`).
+	id uint64
+	// What it might start with (see `Choice`).
+	first *firstSet
 }
 
+var nextParserID atomic.Uint64
+
 // Artificial struct because Golang can't handle polymorphic
 // aliases. :sad_panda:
 type M[A any] struct {
@@ -132,33 +290,54 @@ func fail[A any]() M[A] {
 	}
 }
 
-func makeParser[A any](core func(src source) M[A]) Parser[A] {
+func makeParser[A any](core func(src *source) M[A]) Parser[A] {
 	return Parser[A]{
-		core:  core,
-		cache: nil,
+		core: core,
+		id:   nextParserID.Add(1),
 	}
 }
 
 func OneOf(p func(byte) bool) Parser[byte] {
-	return makeParser(
-		func(src source) M[byte] {
+	var desc string
+	var once sync.Once
+
+	describe := func() string {
+		once.Do(func() { desc = describeClass(p) })
+
+		return desc
+	}
+
+	first := &firstSet{
+		fail: func(src *source, ix int) {
+			src.expected(ix, describe())
+		},
+	}
+
+	for b := range first.bytes {
+		first.bytes[b] = p(byte(b))
+	}
+
+	return withFirst(makeParser(
+		func(src *source) M[byte] {
 			return Bind(
 				getSt(),
 				func(ix int) M[byte] {
-					if ix < len(src.str) && p(src.str[ix]) {
+					if c, ok := src.byteAt(ix); ok && p(c) {
 						return Bind(
 							setSt(ix+1),
 							func(data.Unit) M[byte] {
-								return Return(src.str[ix])
+								return Return(c)
 							},
 						)
 					}
 
+					src.expected(ix, describe())
+
 					return fail[byte]()
 				},
 			)
 		},
-	)
+	), first)
 }
 
 func Chr(c byte) Parser[byte] {
@@ -175,9 +354,21 @@ func NoneOf(p func(byte) bool) Parser[byte] {
 
 func Txt(v string) Parser[string] {
 	vLen := len(v)
+	desc := strconv.Quote(v)
 
-	return makeParser(
-		func(src source) M[string] {
+	var first *firstSet
+
+	if vLen > 0 {
+		first = &firstSet{
+			fail: func(src *source, ix int) {
+				src.expected(ix, desc)
+			},
+		}
+		first.bytes[v[0]] = true
+	}
+
+	return withFirst(makeParser(
+		func(src *source) M[string] {
 			return Bind(
 				getSt(),
 				func(ix int) M[string] {
@@ -192,10 +383,19 @@ func Txt(v string) Parser[string] {
 							)
 						}
 
-						if i < vLen && ix+i < len(src.str) && v[i] == src.str[ix+i] {
+						if c, ok := src.byteAt(ix + i); ok && v[i] == c {
 							return loop(i + 1)
 						}
 
+						src.expected(ix, desc)
+
+						return fail[string]()
+					}
+
+					// (Which would otherwise match no tokens.)
+					if !src.textQ() {
+						src.expected(ix, desc)
+
 						return fail[string]()
 					}
 
@@ -203,38 +403,12 @@ func Txt(v string) Parser[string] {
 				},
 			)
 		},
-	)
-}
-
-// You better believe that we're using PCREs. It's the only
-// redeeming bit of Perl.
-func Regexp(reg string) Parser[string] {
-	r := pcre.MustCompile("^"+reg, 0)
-	return makeParser(func(src source) M[string] {
-		return Bind(
-			getSt(),
-			func(ix int) M[string] {
-				s := src.str[ix:]
-				m := r.MatcherString(s, 0)
-				if !m.Matches() {
-					return fail[string]()
-				}
-
-				sP := m.GroupString(0)
-				return Bind(
-					setSt(ix+len(sP)),
-					func(data.Unit) M[string] {
-						return Return(sP)
-					},
-				)
-			},
-		)
-	})
+	), first)
 }
 
 func Seq[A, B any](p1 Parser[A], p2 Parser[B]) Parser[data.Pair[A, B]] {
-	return makeParser(
-		func(src source) M[data.Pair[A, B]] {
+	return withFirst(makeParser(
+		func(src *source) M[data.Pair[A, B]] {
 			return Bind(
 				p1.core(src),
 				func(v1 A) M[data.Pair[A, B]] {
@@ -247,29 +421,67 @@ func Seq[A, B any](p1 Parser[A], p2 Parser[B]) Parser[data.Pair[A, B]] {
 				},
 			)
 		},
-	)
+	), p1.first)
 }
 
+// Note that there's nothing to do here to combine the
+// alternatives' errors: Whichever of them got farther will
+// have left its expectations in the `source`.
 func Alt[A any](p1, p2 Parser[A]) Parser[A] {
-	return makeParser(
-		func(src source) M[A] {
+	return withFirst(makeParser(
+		func(src *source) M[A] {
 			return M[A]{
 				func(ix int) Result[A] {
+					cuts, errs, st := src.cuts, len(src.errs), src.state
+
+					src.mark(ix)
 					r := p1.core(src).f(ix)
-					if r.SuccessQ() {
+					src.unmark()
+
+					if r.SuccessQ() || src.cuts != cuts {
 						return r
 					}
 
+					src.errs, src.state = src.errs[:errs], st
+
 					return p2.core(src).f(ix)
 				},
 			}
 		},
+	), firstUnion(p1.first, p2.first))
+}
+
+// No backtracking past here: Once `p1` of an `Alt` has got
+// past a `Cut`, `p2` isn't tried if `p1` then fails, and
+// likewise for any `Alt` (or `Rep`) enclosing it; instead,
+// the failure goes all the way up. (Cf. 'A Packrat Parser
+// Generator with Cut' (K.Mizushima, A.Maeda, Y.Yamaguchi,
+// 2010).) So, e.g., a keyword followed by a `Cut` means that
+// an error in whatever follows it is reported as such,
+// rather than as a failure to parse something else that
+// just happened to start there. It also means that the
+// memo entries (and, for `ParseReader`, the input) from
+// before the cut can go.
+func Cut() Parser[data.Unit] {
+	return makeParser(
+		func(src *source) M[data.Unit] {
+			return M[data.Unit]{
+				func(ix int) Result[data.Unit] {
+					src.cuts++
+					src.cutIx = ix
+
+					src.forget(src.floor(ix))
+
+					return success[data.Unit]{data.Unit{}, ix}
+				},
+			}
+		},
 	)
 }
 
 func Guard[A any](p Parser[A], f func(A) bool) Parser[A] {
-	return makeParser(
-		func(src source) M[A] {
+	return withFirst(makeParser(
+		func(src *source) M[A] {
 			return Bind(
 				p.core(src),
 				func(v A) M[A] {
@@ -281,12 +493,12 @@ func Guard[A any](p Parser[A], f func(A) bool) Parser[A] {
 				},
 			)
 		},
-	)
+	), p.first)
 }
 
 func Proc[A, B any](p Parser[A], f func(A) B) Parser[B] {
-	return makeParser(
-		func(src source) M[B] {
+	return withFirst(makeParser(
+		func(src *source) M[B] {
 			return Bind(
 				p.core(src),
 				func(v A) M[B] {
@@ -294,137 +506,267 @@ func Proc[A, B any](p Parser[A], f func(A) B) Parser[B] {
 				},
 			)
 		},
-	)
+	), p.first)
 }
 
 func ParserJust[A any](v A) Parser[A] {
 	return makeParser(
-		func(source) M[A] {
+		func(*source) M[A] {
 			return Return(v)
 		},
 	)
 }
 
-func ParserFail[A any](string) Parser[A] {
+func ParserFail[A any](msg string) Parser[A] {
 	return makeParser(
-		func(source) M[A] {
-			return fail[A]()
+		func(src *source) M[A] {
+			return M[A]{
+				func(ix int) Result[A] {
+					src.message(ix, msg)
+
+					return failure[A]{}
+				},
+			}
 		},
 	)
 }
 
-// This is always so much nicer in a lazy language; need
-// something to avoid eagerly evaluating, e.g., the RHS of
-// an `Alt` to avoid divergence.
-func Cache[A any](lz data.Lazy[Parser[A]]) Parser[A] {
-	return makeParser(
-		func(src source) M[A] {
+// Describe `p` by `name` in error messages. If `p` fails
+// without getting anywhere, the nitty-gritty of what it
+// expected (e.g., a list of regexps) is replaced by `name`;
+// if it gets farther than that, the details are probably
+// more helpful, so they're left alone.
+func Label[A any](p Parser[A], name string) Parser[A] {
+	var first *firstSet
+
+	if p.first != nil {
+		first = &firstSet{
+			bytes: p.first.bytes,
+			fail: func(src *source, ix int) {
+				src.expected(ix, name)
+			},
+		}
+	}
+
+	return withFirst(makeParser(
+		func(src *source) M[A] {
 			return M[A]{
 				func(ix int) Result[A] {
-					if ix > len(src.str) {
-						return failure[A]{}
-					}
-
-					p := lz.Force()
+					errIx, expect := src.errIx, slices.Clone(src.expect)
 
-					arr := p.cache
+					r := p.core(src).f(ix)
 
-					if p.cache == nil {
-						arr = make([]data.Maybe[Result[A]], len(src.str))
-						for i := 0; i < len(src.str); i++ {
-							arr[i] = data.Nothing[Result[A]]{}
+					if src.errIx == ix {
+						if errIx != ix {
+							expect = nil
 						}
 
-						p.cache = arr
-
-						src.undo = append(
-							src.undo,
-							func() {
-								p.cache = nil
-							},
-						)
-					}
-
-					var res Result[A]
-
-					mFl := arr[ix]
-
-					if mFl.JustQ() {
-						res = mFl.GetJust()
-					} else {
-						res = p.core(src).f(ix)
-
-						arr[ix] = data.MkJust(res)
+						src.expect = expect
+						src.expected(ix, name)
 					}
 
-					if res.SuccessQ() {
-						v, ix := res.GetSuccess()
+					return r
+				},
+			}
+		},
+	), first)
+}
 
-						return success[A]{v, ix}
-					}
+// This is always so much nicer in a lazy language; need
+// something to avoid eagerly evaluating, e.g., the RHS of
+// an `Alt` to avoid divergence.
+//
+// The memo tables live in the `source` (so they last for
+// exactly one `Parse`) and are keyed by the *forced*
+// parser; as all copies of a lazy cell force the same
+// parser, every `Cache` of a given cell shares one table.
+//
+// Left recursion (direct or indirect, e.g., `expr ::= expr
+// "-" term | term`) is fine, provided that the recursion
+// goes through a `Cache`; see 'memo.go'.
+func Cache[A any](lz data.Lazy[Parser[A]]) Parser[A] {
+	return makeParser(
+		func(src *source) M[A] {
+			return M[A]{
+				func(ix int) Result[A] {
+					p := lz.Force()
 
-					return failure[A]{}
+					return applyRule(src, p, ix)
 				},
 			}
 		},
 	)
 }
 
+// A loop rather than recursion, so that, e.g., the lines of
+// a long file don't each take a stack frame.
 func Rep[A any](p Parser[A]) Parser[[]A] {
 	return makeParser(
-		func(src source) M[[]A] {
-			var loop func([]A) M[[]A]
+		func(src *source) M[[]A] {
+			return M[[]A]{
+				func(ix int) Result[[]A] {
+					vs := []A{}
+
+					for {
+						cuts, errs, st := src.cuts, len(src.errs), src.state
 
-			loop = func(vs []A) M[[]A] {
-				return M[[]A]{
-					func(ix int) Result[[]A] {
+						src.mark(ix)
 						r := p.core(src).f(ix)
+						src.unmark()
 
-						if r.SuccessQ() {
-							v, ix := r.GetSuccess()
+						if r.FailureQ() && src.cuts != cuts {
+							return failure[[]A]{}
+						}
 
-							return loop(append(vs, v)).f(ix)
+						if r.FailureQ() {
+							src.errs, src.state = src.errs[:errs], st
+
+							return success[[]A]{vs, ix}
 						}
 
-						return success[[]A]{vs, ix}
-					},
-				}
+						var v A
+						v, ix = r.GetSuccess()
+						vs = append(vs, v)
+					}
+				},
 			}
-
-			return loop([]A{})
 		},
 	)
 }
 
-// This is actually 'end of string'.
+// This is actually 'end of string' (or of the tokens).
 func Eof() Parser[data.Unit] {
-	return Peek(func(str string, ix int) bool {
-		return ix == len(str)
-	})
+	return peek(
+		func(src *source, ix int) bool {
+			return src.atEnd(ix)
+		},
+		"end of input",
+	)
 }
 
 // Check end of word / end of string. (Useful, e.g., to
 // force some tokenisation constraints.)
 func Eow() Parser[data.Unit] {
-	return Peek(func(str string, ix int) bool {
-		return ix == len(str) || (ix >= 0 && ix < len(str) && str[ix] == ' ')
+	return EowOf(func(c byte) bool {
+		return c != ' '
 	})
 }
 
+// End of a word made of `wordQ` bytes, e.g., so that a
+// keyword parser for `if` won't match the start of `iffy`:
+//
+//	SeqLeft(Txt("if"), EowOf(identQ))
+func EowOf(wordQ func(byte) bool) Parser[data.Unit] {
+	return Label(NotFollowedBy(OneOf(wordQ)), "end of word")
+}
+
 // Generalised 'raw' guard. Note that it never advances the
 // stream pointer; only allows inspection of the state.
 func Peek(g func(string, int) bool) Parser[data.Unit] {
+	return peek(
+		func(src *source, ix int) bool {
+			if !src.textQ() {
+				return false
+			}
+
+			src.need(ix, ix+1)
+
+			return g(src.str, ix-src.base)
+		},
+		"",
+	)
+}
+
+// Since we can't know what an arbitrary guard is looking
+// for, it's up to the caller to describe it (or not).
+func peek(g func(*source, int) bool, what string) Parser[data.Unit] {
 	return makeParser(
-		func(src source) M[data.Unit] {
+		func(src *source) M[data.Unit] {
 			return M[data.Unit]{
 				func(ix int) Result[data.Unit] {
-					if g(src.str, ix) {
+					if g(src, ix) {
 						return success[data.Unit]{data.Unit{}, ix}
 					}
 
+					src.expected(ix, what)
+
 					return failure[data.Unit]{}
 				},
 			}
 		},
 	)
 }
+
+// PEG's `&p`: Succeeds (with `p`'s value) if `p` does, but
+// without consuming anything.
+func LookAhead[A any](p Parser[A]) Parser[A] {
+	return makeParser(
+		func(src *source) M[A] {
+			return M[A]{
+				func(ix int) Result[A] {
+					st := src.errState()
+
+					r := lookAhead(src, p, ix)
+					if r.FailureQ() {
+						return r
+					}
+
+					// We're not interested in how `p` got there.
+					src.setErrState(st)
+
+					v, _ := r.GetSuccess()
+
+					return success[A]{v, ix}
+				},
+			}
+		},
+	)
+}
+
+// PEG's `!p`: Succeeds (without consuming anything) if `p`
+// fails, and vice versa. (`Label` it to say what was
+// expected instead.)
+func NotFollowedBy[A any](p Parser[A]) Parser[data.Unit] {
+	return makeParser(
+		func(src *source) M[data.Unit] {
+			return M[data.Unit]{
+				func(ix int) Result[data.Unit] {
+					st := src.errState()
+
+					r := lookAhead(src, p, ix)
+
+					src.setErrState(st)
+
+					if r.FailureQ() {
+						return success[data.Unit]{data.Unit{}, ix}
+					}
+
+					if _, ixP := r.GetSuccess(); ixP > ix && src.toks == nil {
+						src.message(ix, "unexpected "+strconv.Quote(src.slice(ix, ixP)))
+					} else {
+						src.expected(ix, "")
+					}
+
+					return failure[data.Unit]{}
+				},
+			}
+		},
+	)
+}
+
+// Whatever happens in a lookahead stays there: It doesn't
+// cut anything (nor record any errors, nor change the state)
+// outside of itself.
+// Pinning `ix` means that `ParseReader` can't forget it,
+// cut or no cut.
+func lookAhead[A any](src *source, p Parser[A], ix int) Result[A] {
+	cuts, cutIx, errs, st := src.cuts, src.cutIx, len(src.errs), src.state
+
+	src.pin(ix)
+	r := p.core(src).f(ix)
+	src.unpin()
+
+	src.cuts, src.cutIx, src.errs, src.state = cuts, cutIx, src.errs[:errs], st
+
+	return r
+}
@@ -20,7 +20,10 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -79,6 +82,93 @@ func TestMonadAssociativity(t *testing.T) {
 	assert.Equal(t, Bind(Bind(m, g), h).f(0), Bind(m, func(x int) M[int] { return Bind(g(x), h) }).f(0))
 }
 
+// The same laws, for parsers (with `Then` as `>>=`): The
+// two sides should agree on every input, good or bad.
+func assertParsersAgree[A any](t *testing.T, p1, p2 Parser[A]) {
+	t.Helper()
+
+	for _, s := range []string{"", "3", "3abc", "12abcdefghijkl", "2a", "x"} {
+		assert.Equal(t, Parse(p1, s), Parse(p2, s), s)
+	}
+}
+
+func digit() Parser[int] {
+	return Proc(OneOf(func(b byte) bool { return '0' <= b && b <= '9' }), func(b byte) int { return int(b - '0') })
+}
+
+// `n` letters.
+func letters(n int) Parser[string] {
+	return Guard(Regexp("[a-z]*"), func(s string) bool { return len(s) == n })
+}
+
+func TestThenLeftIdentity(t *testing.T) {
+	assertParsersAgree(t, Then(ParserJust(3), letters), letters(3))
+}
+
+func TestThenRightIdentity(t *testing.T) {
+	assertParsersAgree(t, Then(digit(), ParserJust[int]), digit())
+}
+
+func TestThenAssociativity(t *testing.T) {
+	h := func(s string) Parser[int] {
+		return Proc(Regexp("[a-z]*"), func(sP string) int { return len(s) + len(sP) })
+	}
+
+	assertParsersAgree(
+		t,
+		Then(Then(digit(), letters), h),
+		Then(digit(), func(n int) Parser[int] { return Then(letters(n), h) }),
+	)
+}
+
+func TestMapLaws(t *testing.T) {
+	f := func(n int) int { return n * 2 }
+	g := strconv.Itoa
+
+	assertParsersAgree(t, Map(digit(), func(n int) int { return n }), digit())
+	assertParsersAgree(t, Map(Map(digit(), f), g), Map(digit(), func(n int) string { return g(f(n)) }))
+}
+
+func TestApLaws(t *testing.T) {
+	f := func(n int) string { return strconv.Itoa(n * 2) }
+
+	// pure id <*> v ≡ v
+	assertParsersAgree(t, Ap(ParserJust(func(n int) int { return n }), digit()), digit())
+	// pure f <*> pure x ≡ pure (f x)
+	assertParsersAgree(t, Ap(ParserJust(f), ParserJust(3)), ParserJust(f(3)))
+	// u <*> pure y ≡ pure ($ y) <*> u
+	u := Map(digit(), func(n int) func(int) int { return func(m int) int { return n * m } })
+	assertParsersAgree(
+		t,
+		Ap(u, ParserJust(5)),
+		Ap(ParserJust(func(g func(int) int) int { return g(5) }), u),
+	)
+}
+
+// The point of `Then`: What to parse next depends on what
+// came before.
+func TestThenLengthPrefix(t *testing.T) {
+	p := SeqLeft(Then(digit(), letters), Eof())
+
+	for _, c := range []struct {
+		s  string
+		ok bool
+	}{
+		{"0", true},
+		{"3abc", true},
+		{"3ab", false},
+		{"3abcd", false},
+		{"x", false},
+	} {
+		assert.Equal(t, c.ok, Parse(p, c.s).SuccessQ(), c.s)
+	}
+
+	r := Parse(p, "x")
+
+	require.True(t, r.FailureQ())
+	assert.Equal(t, []string{"[0-9]"}, r.GetFailure().Expected)
+}
+
 func TestSetStGetStCoherent(t *testing.T) {
 	n := 5
 	m := Bind(
@@ -228,7 +318,25 @@ func TestTxt(t *testing.T) {
 	}
 }
 
+// The regexp backends should agree (as far as their syntax
+// does).
+var regexps = []struct {
+	lab    string
+	regexp func(string) Parser[string]
+}{
+	{"pcre", Regexp},
+	{"re2", RegexpRE2},
+}
+
 func TestRegexp(t *testing.T) {
+	for _, c := range regexps {
+		t.Run(c.lab, func(t *testing.T) {
+			testRegexp(t, c.regexp)
+		})
+	}
+}
+
+func testRegexp(t *testing.T, Regexp func(string) Parser[string]) {
 	ss := []string{
 		"foo",
 		"foozle",
@@ -320,15 +428,135 @@ func TestRegexp(t *testing.T) {
 }
 
 func TestRegexpRegression(t *testing.T) {
-	p := Regexp("[ab]+")
+	for _, c := range regexps {
+		t.Run(c.lab, func(t *testing.T) {
+			p := c.regexp("[ab]+")
+
+			r1 := Parse(p, "fooabab")
+			assert.True(t, r1.FailureQ())
 
-	r1 := Parse(p, "fooabab")
-	assert.True(t, r1.FailureQ())
+			r2 := Parse(p, "aaabbbfooba")
+			require.True(t, r2.SuccessQ())
+			s, _ := r2.GetSuccess()
+			assert.Equal(t, "aaabbb", s)
+		})
+	}
+}
 
-	r2 := Parse(p, "aaabbbfooba")
-	require.True(t, r2.SuccessQ())
-	s, _ := r2.GetSuccess()
-	assert.Equal(t, "aaabbb", s)
+var regexpGroups = []struct {
+	lab    string
+	groups func(string) Parser[[]string]
+	named  func(string) Parser[map[string]string]
+}{
+	{"pcre", RegexpGroups, RegexpNamed},
+	{"re2", RegexpGroupsRE2, RegexpNamedRE2},
+}
+
+func TestRegexpGroups(t *testing.T) {
+	const float = `(?P<sign>[-+]?)(?P<mant>[0-9]+(?:\.[0-9]*)?)(?:[eE](?P<exp>[-+]?[0-9]+))?`
+
+	for _, c := range regexpGroups {
+		t.Run(c.lab, func(t *testing.T) {
+			r := Parse(Seq(Txt("x="), c.groups(float)), "x=-1.5e10;")
+			require.True(t, r.SuccessQ())
+
+			v, _ := r.GetSuccess()
+			assert.Equal(t, []string{"-1.5e10", "-", "1.5", "10"}, v.Second())
+
+			// An unmatched group is "" (as is an empty one).
+			r1 := Parse(c.groups(float), "42")
+			require.True(t, r1.SuccessQ())
+
+			gs, _ := r1.GetSuccess()
+			assert.Equal(t, []string{"42", "", "42", ""}, gs)
+
+			// ... but only a group that took part in the match
+			// is named.
+			p := Proc(c.named(float), func(m map[string]string) string {
+				return m["sign"] + "|" + m["mant"] + "|" + m["exp"]
+			})
+
+			r2 := Parse(SeqLeft(p, Txt(";")), "1e-3;")
+			require.True(t, r2.SuccessQ())
+
+			s, _ := r2.GetSuccess()
+			assert.Equal(t, "|1|-3", s)
+
+			m, _ := Parse(c.named(float), "7").GetSuccess()
+			assert.Equal(t, map[string]string{"sign": "", "mant": "7"}, m)
+
+			e := Parse(c.groups(float), "x").GetFailure()
+			assert.Equal(t, 0, e.Offset)
+			assert.Equal(t, []string{float}, e.Expected)
+		})
+	}
+}
+
+// A match can run on well past the first bit of input that
+// the matcher is given.
+func TestRegexpLong(t *testing.T) {
+	s := strings.Repeat("ab", 5000)
+
+	for _, c := range regexps {
+		t.Run(c.lab, func(t *testing.T) {
+			r := Parse(Seq(c.regexp("(?:ab)+"), Txt("c")), s+"c")
+			require.True(t, r.SuccessQ())
+
+			v, _ := r.GetSuccess()
+			assert.Equal(t, s, v.First())
+
+			e := Parse(SeqLeft(c.regexp("(?:ab)+"), Eof()), s+"a").GetFailure()
+			assert.Equal(t, len(s), e.Offset)
+		})
+	}
+}
+
+// Matching shouldn't cost anything like the length of the
+// rest of the input, so the throughput (MB/s) should be about
+// the same at each size.
+func BenchmarkRegexp(b *testing.B) {
+	for _, c := range regexps {
+		for _, n := range []int{1 << 18, 1 << 19, 1 << 20} {
+			b.Run(fmt.Sprintf("%s/%d", c.lab, n), func(b *testing.B) {
+				s := strings.Repeat("foozle bopper ", n/14)
+				p := SeqLeft(Rep(SeqLeft(c.regexp("[a-z]+"), Txt(" "))), Eof())
+
+				b.SetBytes(int64(len(s)))
+
+				for i := 0; i < b.N; i++ {
+					if !Parse(p, s).SuccessQ() {
+						b.Fatal("no parse")
+					}
+				}
+			})
+		}
+	}
+}
+
+// The whole pattern (alternatives and all) is anchored at
+// the current offset, whichever the backend.
+func TestRegexpAnchored(t *testing.T) {
+	for _, c := range regexps {
+		t.Run(c.lab, func(t *testing.T) {
+			p := SeqLeft(c.regexp("a|b+"), Eof())
+
+			assert.True(t, Parse(p, "a").SuccessQ())
+			assert.True(t, Parse(p, "bb").SuccessQ())
+			assert.True(t, Parse(p, "cb").FailureQ())
+			assert.True(t, Parse(c.regexp("a|b+"), "cb").FailureQ())
+
+			r := Parse(Seq(Txt("x"), c.regexp("[0-9]+")), "x1y")
+
+			require.True(t, r.SuccessQ())
+
+			v, _ := r.GetSuccess()
+			assert.Equal(t, data.MkPair("x", "1"), v)
+
+			e := Parse(Seq(Txt("x"), c.regexp("[0-9]+")), "xy").GetFailure()
+			assert.Equal(t, 1, e.Offset)
+			assert.Equal(t, []string{"[0-9]+"}, e.Expected)
+		})
+	}
 }
 
 func TestSeq(t *testing.T) {
@@ -424,6 +652,93 @@ func TestAlt(t *testing.T) {
 	})
 }
 
+func TestCut(t *testing.T) {
+	kw := func(s string) Parser[string] {
+		return SeqLeft(Txt(s), Cut())
+	}
+
+	t.Run("alt", func(t *testing.T) {
+		ifP := SeqRight(kw("if"), Txt("("))
+		p := Alt(ifP, Regexp("[a-z]+"))
+
+		r := Parse(p, "ifx")
+
+		require.True(t, r.FailureQ())
+
+		e := r.GetFailure()
+		assert.Equal(t, 2, e.Offset)
+		assert.Equal(t, []string{`"("`}, e.Expected)
+
+		// Without the cut, it's just a name.
+		r = Parse(Alt(SeqRight(Txt("if"), Txt("(")), Regexp("[a-z]+")), "ifx")
+
+		require.True(t, r.SuccessQ())
+
+		// Nor does an enclosing `Alt` get to try.
+		assert.True(t, Parse(Alt(p, Txt("ifx")), "ifx").FailureQ())
+
+		// Cuts in alternatives not taken don't count.
+		assert.True(t, Parse(Alt(SeqRight(kw("if"), Txt("(")), Txt("x")), "x").SuccessQ())
+	})
+
+	t.Run("rep", func(t *testing.T) {
+		p := Rep(SeqRight(kw("let "), Txt("x;")))
+
+		r := Parse(p, "let x;let x;")
+
+		require.True(t, r.SuccessQ())
+
+		vs, _ := r.GetSuccess()
+		assert.Len(t, vs, 2)
+
+		r = Parse(p, "let x;let y;")
+
+		require.True(t, r.FailureQ())
+		assert.Equal(t, 10, r.GetFailure().Offset)
+	})
+
+	t.Run("left recursion", func(t *testing.T) {
+		var expr data.Lazy[Parser[string]]
+		expr = data.MkLazy(func() Parser[string] {
+			return Alt(
+				Proc(Seq(SeqLeft(Cache(expr), kw("-")), numP), binOp("-")),
+				numP,
+			)
+		})
+
+		assert.Equal(t, "((1 - 2) - 3)", parseAll(t, Cache(expr), "1-2-3"))
+
+		r := Parse(Cache(expr), "1-2-")
+
+		require.True(t, r.FailureQ())
+
+		e := r.GetFailure()
+		assert.Equal(t, 4, e.Offset)
+		assert.Equal(t, []string{"[0-9]+"}, e.Expected)
+	})
+
+	t.Run("memo", func(t *testing.T) {
+		// A cut a line, so only about a line's worth of memo
+		// entries is kept.
+		line := Cache(data.MkLazy(func() Parser[string] {
+			return SeqLeft(SeqRight(kw("x="), numP), Chr('\n'))
+		}))
+
+		src := newSource(strings.Repeat("x=12\n", 1000))
+
+		r := run(Alt(SeqLeft(Rep(line), Eof()), ParserFail[[]string]("no")), src)
+
+		require.True(t, r.SuccessQ())
+
+		memo := 0
+		for _, t := range src.memo {
+			memo += t.size()
+		}
+
+		assert.Less(t, memo, 10)
+	})
+}
+
 func TestParserJust(t *testing.T) {
 	ss := []string{
 		"foo",
@@ -514,6 +829,122 @@ func TestCache(t *testing.T) {
 	}
 }
 
+// The end of the input is a perfectly good offset for a
+// `Cache` (at which, e.g., `Eof` succeeds).
+func TestCacheAtEnd(t *testing.T) {
+	end := data.MkLazy(func() Parser[string] {
+		return Alt(Txt("!"), ParserJust("end"))
+	})
+
+	for _, s := range []string{"", "ab"} {
+		p := SeqLeft(Seq(Txt(s), Seq(Cache(end), Cache(end))), Eof())
+
+		r := Parse(p, s)
+		require.True(t, r.SuccessQ(), "parsing %q", s)
+
+		v, _ := r.GetSuccess()
+		assert.Equal(t, data.MkPair(s, data.MkPair("end", "end")), v)
+	}
+}
+
+// Without memoisation, each level of nesting doubles the
+// work (as both alternatives parse the same bracketed
+// expression).
+func TestCacheLinear(t *testing.T) {
+	calls := 0
+	count := Peek(func(string, int) bool {
+		calls++
+
+		return true
+	})
+
+	var e data.Lazy[Parser[int]]
+	e = data.MkLazy(func() Parser[int] {
+		inner := SeqRight(count, SeqRight(Txt("("), SeqLeft(Cache(e), Txt(")"))))
+		one := Proc(Txt("x"), func(string) int { return 0 })
+
+		return Alt(
+			Proc(SeqLeft(inner, Txt("!")), func(n int) int { return n + 1 }),
+			Alt(
+				Proc(inner, func(n int) int { return n + 1 }),
+				one,
+			),
+		)
+	})
+
+	for _, n := range []int{10, 100, 1000} {
+		calls = 0
+
+		s := strings.Repeat("(", n) + "x" + strings.Repeat(")", n)
+		r := Parse(SeqLeft(Cache(e), Eof()), s)
+
+		require.True(t, r.SuccessQ())
+
+		v, _ := r.GetSuccess()
+
+		assert.Equal(t, n, v)
+		assert.LessOrEqual(t, calls, 2*(n+1))
+	}
+}
+
+// Run with `-race`: The same (cached) parser is used by
+// all of the goroutines. (Results are checked afterwards, as
+// `assert`'s locking would hide races.)
+func TestCacheConcurrent(t *testing.T) {
+	var list data.Lazy[Parser[[]string]]
+	list = data.MkLazy(func() Parser[[]string] {
+		return Alt(
+			Proc(
+				Seq(Regexp("[a-z]+"), SeqRight(Txt(","), Cache(list))),
+				func(p data.Pair[string, []string]) []string {
+					return append([]string{p.First()}, p.Second()...)
+				},
+			),
+			Proc(Regexp("[a-z]+"), func(s string) []string { return []string{s} }),
+		)
+	})
+	p := SeqLeft(Cache(list), Eof())
+
+	n := 32
+	res := make([][]Result[[]string], n)
+
+	var wg sync.WaitGroup
+
+	start := make(chan struct{})
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			<-start
+
+			s := strings.Repeat("foo,", i) + "bar"
+
+			for j := 0; j < 50; j++ {
+				res[i] = append(res[i], Parse(p, s), Parse(p, s+","))
+			}
+		}(i)
+	}
+
+	close(start)
+	wg.Wait()
+
+	for i := 0; i < n; i++ {
+		exp := strings.Split(strings.Repeat("foo,", i)+"bar", ",")
+
+		for j := 0; j < len(res[i]); j += 2 {
+			require.True(t, res[i][j].SuccessQ())
+
+			ss, _ := res[i][j].GetSuccess()
+
+			assert.Equal(t, exp, ss)
+			assert.True(t, res[i][j+1].FailureQ())
+		}
+	}
+}
+
 func TestRep(t *testing.T) {
 	ss := []string{
 		"foo",
@@ -602,3 +1033,126 @@ func TestWord(t *testing.T) {
 	p3 := Parse(SeqLeft(Txt("foo"), Eow()), s2)
 	require.True(t, p3.SuccessQ())
 }
+
+func TestEowOf(t *testing.T) {
+	identQ := func(c byte) bool {
+		return c == '_' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+	}
+	ifP := SeqLeft(Txt("if"), EowOf(identQ))
+
+	for _, s := range []string{"if", "if (x)", "if("} {
+		assert.True(t, Parse(ifP, s).SuccessQ(), s)
+	}
+
+	r := Parse(ifP, "iffy")
+
+	require.True(t, r.FailureQ())
+	assert.Equal(t, `line 1, column 3: unexpected "f"; expected end of word`, r.GetFailure().Error())
+}
+
+func TestLookAhead(t *testing.T) {
+	p := Seq(LookAhead(Txt("ab")), Txt("abc"))
+
+	r := Parse(p, "abc")
+
+	require.True(t, r.SuccessQ())
+
+	v, ix := r.GetSuccess()
+	assert.Equal(t, data.MkPair("ab", "abc"), v)
+	assert.Equal(t, 3, ix)
+
+	r = Parse(p, "ac")
+
+	require.True(t, r.FailureQ())
+	assert.Equal(t, []string{`"ab"`}, r.GetFailure().Expected)
+}
+
+func TestNotFollowedBy(t *testing.T) {
+	// Anything but `*/`.
+	notEnd := SeqRight(NotFollowedBy(Txt("*/")), OneOf(func(byte) bool { return true }))
+	comment := SeqRight(Txt("/*"), SeqLeft(Recognize(Rep(notEnd)), Txt("*/")))
+
+	r := Parse(comment, "/* a * b / c */")
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, " a * b / c ", v)
+
+	r = Parse(comment, "/* a")
+
+	require.True(t, r.FailureQ())
+	assert.ElementsMatch(t, []string{"any character", `"*/"`}, r.GetFailure().Expected)
+}
+
+// What happens in a lookahead, stays there.
+func TestLookAheadCut(t *testing.T) {
+	p := Alt(
+		SeqRight(NotFollowedBy(SeqRight(Seq(Txt("a"), Cut()), Txt("x"))), Txt("b")),
+		Txt("ab"),
+	)
+
+	r := Parse(p, "ab")
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, "ab", v)
+}
+
+func TestOneOfR(t *testing.T) {
+	p := OneOfR(unicode.IsLetter)
+
+	for _, c := range []struct {
+		s  string
+		ok bool
+	}{
+		{"a", true},
+		{"é", true},
+		{"∀x", false},
+		{"λx", true},
+		{"日本", true},
+		{"1", false},
+		{"\xc3", false},
+		{"", false},
+	} {
+		r := Parse(p, c.s)
+
+		if c.ok {
+			require.True(t, r.SuccessQ(), c.s)
+
+			exp, w := utf8.DecodeRuneInString(c.s)
+			assert.Equal(t, success[rune]{exp, w}, r)
+		} else {
+			assert.True(t, r.FailureQ(), c.s)
+		}
+	}
+}
+
+func TestChrR(t *testing.T) {
+	p := Seq(ChrR('→'), ChrR('∀'))
+
+	r := Parse(p, "→∀x")
+	require.True(t, r.SuccessQ())
+	v, ix := r.GetSuccess()
+	assert.Equal(t, data.MkPair('→', '∀'), v)
+	assert.Equal(t, len("→∀"), ix)
+
+	r = Parse(p, "→x")
+	require.True(t, r.FailureQ())
+	assert.Equal(t, []string{`"∀"`}, r.GetFailure().Expected)
+	assert.Equal(t, 2, r.GetFailure().Col)
+
+	// The first byte of '→' is no good on its own.
+	assert.True(t, Parse(ChrR('→'), "→"[:1]).FailureQ())
+}
+
+func TestNoneOfR(t *testing.T) {
+	p := Rep(NoneOfR(func(c rune) bool { return c == '→' }))
+
+	r := Parse(p, "añb→c")
+	require.True(t, r.SuccessQ())
+	v, ix := r.GetSuccess()
+	assert.Equal(t, []rune("añb"), v)
+	assert.Equal(t, len("añb"), ix)
+}
@@ -0,0 +1,146 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"slices"
+)
+
+// Panic-mode error recovery: If `p` fails, note the error,
+// skip ahead to just past the next `sync` (e.g., the end of
+// the line), and carry on as though `p` had produced
+// `fallback`. (If there's no next `sync`, that's the end of
+// the input; if we're already there, `Recover` fails.) The
+// parse as a whole then succeeds (assuming that nothing else
+// goes wrong), and `GetErrors` has everything that went
+// wrong along the way.
+//
+// Note that this works even if `p` fails after a `Cut`
+// (although the cut still applies to whatever encloses the
+// `Recover`). Errors from parses that are backed out of
+// (e.g., the first branch of an `Alt` that fails) don't
+// count.
+func Recover[A, B any](p Parser[A], sync Parser[B], fallback A) Parser[A] {
+	return makeParser(
+		func(src *source) M[A] {
+			return M[A]{
+				func(ix int) Result[A] {
+					// The error is about `p`, not whatever came
+					// before it.
+					st := src.errState()
+					src.errIx, src.expect, src.msgs = ix, nil, nil
+
+					errs, state := len(src.errs), src.state
+
+					// The window mustn't move on from `ix` (on
+					// which `p`'s error, and thus the `sync`
+					// scan, hangs) whatever `p` gets up to.
+					src.pin(ix)
+					defer src.unpin()
+
+					r := p.core(src).f(ix)
+					if r.SuccessQ() {
+						src.mergeErrState(st)
+
+						return r
+					}
+
+					// Carry on as though `p` hadn't happened.
+					src.state = state
+
+					pSt, e := src.errState(), src.parseError()
+
+					k := e.Offset
+					for {
+						src.mark(k)
+						rs := sync.core(src).f(k)
+						src.unmark()
+
+						if rs.SuccessQ() {
+							_, k = rs.GetSuccess()
+
+							break
+						}
+
+						src.state = state
+
+						if src.atEnd(k) {
+							break
+						}
+
+						k++
+					}
+
+					// If we'd get nowhere (e.g., at the end of the
+					// input), `p`'s failure stands (lest `Rep`
+					// recover forever).
+					if k == ix {
+						src.setErrState(pSt)
+						src.mergeErrState(st)
+
+						return failure[A]{}
+					}
+
+					// Nor should we hear about `sync` not
+					// matching.
+					src.setErrState(st)
+					src.errs = append(src.errs[:errs], e)
+
+					return success[A]{fallback, k}
+				},
+			}
+		},
+	)
+}
+
+// The farthest-failure bookkeeping (see `source`).
+type errState struct {
+	ix     int
+	expect []string
+	msgs   []string
+}
+
+func (src *source) errState() errState {
+	return errState{src.errIx, src.expect, src.msgs}
+}
+
+func (src *source) setErrState(st errState) {
+	src.errIx, src.expect, src.msgs = st.ix, st.expect, st.msgs
+}
+
+// As though `st` had been recorded after what we have now.
+func (src *source) mergeErrState(st errState) {
+	switch {
+	case st.ix > src.errIx:
+		src.setErrState(st)
+	case st.ix == src.errIx:
+		src.expect = union(st.expect, src.expect)
+		src.msgs = union(st.msgs, src.msgs)
+	}
+}
+
+func union(ss1, ss2 []string) []string {
+	ss := slices.Clone(ss1)
+
+	for _, s := range ss2 {
+		if !slices.Contains(ss, s) {
+			ss = append(ss, s)
+		}
+	}
+
+	return ss
+}
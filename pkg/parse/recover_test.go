@@ -0,0 +1,123 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+func TestRecover(t *testing.T) {
+	setting := SeqLeft(
+		Seq(SeqLeft(Regexp("[a-z]+"), Txt(" = ")), numP),
+		Chr('\n'),
+	)
+	bad := data.MkPair("?", "?")
+	config := SeqLeft(Rep(Recover(setting, Chr('\n'), bad)), Eof())
+
+	r := Parse(config, "a = 1\nb = x\nc = 3\n!\ne = 5")
+
+	require.True(t, r.SuccessQ())
+
+	vs, _ := r.GetSuccess()
+	assert.Equal(
+		t,
+		[]data.Pair[string, string]{
+			data.MkPair("a", "1"),
+			bad,
+			data.MkPair("c", "3"),
+			bad,
+			// No newline to sync on, so that's the end.
+			bad,
+		},
+		vs,
+	)
+
+	errs := r.GetErrors()
+	require.Len(t, errs, 3)
+	assert.Equal(t, "line 2, column 5: expected [0-9]+", errs[0].Error())
+	assert.Equal(t, "line 4, column 1: expected [a-z]+", errs[1].Error())
+	assert.Equal(t, `line 5, column 6: expected "\n"`, errs[2].Error())
+}
+
+func TestRecoverFailure(t *testing.T) {
+	p := SeqLeft(Recover(Txt("a"), Chr(';'), ""), Txt("!"))
+
+	r := Parse(p, "b;?")
+
+	require.True(t, r.FailureQ())
+
+	errs := r.GetErrors()
+	require.Len(t, errs, 2)
+	assert.Equal(t, 0, errs[0].Offset)
+	assert.Equal(t, 2, errs[1].Offset)
+	assert.Equal(t, r.GetFailure(), errs[1])
+}
+
+func TestRecoverBacktrack(t *testing.T) {
+	a := Recover(Txt("a"), Chr(';'), "?")
+
+	// The first branch recovers, but fails anyway, so its
+	// error doesn't count.
+	r := Parse(Alt(SeqLeft(a, Txt("!")), Txt("b;?")), "b;?")
+
+	require.True(t, r.SuccessQ())
+	assert.Empty(t, r.GetErrors())
+}
+
+func TestRecoverMemo(t *testing.T) {
+	a := Cache(data.MkLazy(func() Parser[string] {
+		return Recover(Txt("a"), Chr(';'), "?")
+	}))
+
+	// The second branch gets `a` from the memo table, and
+	// has to hear about its error again.
+	r := Parse(Alt(SeqLeft(a, Txt("!")), SeqLeft(a, Txt("?"))), "b;?")
+
+	require.True(t, r.SuccessQ())
+	require.Len(t, r.GetErrors(), 1)
+	assert.Equal(t, 0, r.GetErrors()[0].Offset)
+}
+
+// expr ::= expr "+" term | term
+func TestRecoverLeftRecursion(t *testing.T) {
+	term := Recover(numP, Chr(';'), "?")
+
+	var expr data.Lazy[Parser[string]]
+	expr = data.MkLazy(func() Parser[string] {
+		return Alt(
+			Proc(Seq(SeqLeft(Cache(expr), Txt("+")), term), binOp("+")),
+			term,
+		)
+	})
+
+	r := Parse(SeqLeft(Cache(expr), Eof()), "1+x;+2+y;")
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, "(((1 + ?) + 2) + ?)", v)
+
+	errs := r.GetErrors()
+	require.Len(t, errs, 2)
+	assert.Equal(t, 2, errs[0].Offset)
+	assert.Equal(t, 7, errs[1].Offset)
+}
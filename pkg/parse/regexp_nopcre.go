@@ -0,0 +1,33 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+//go:build nopcre
+
+package parse
+
+// Without PCRE (e.g., for `CGO_ENABLED=0`), `Regexp` makes do
+// with `RegexpRE2`.
+func Regexp(reg string) Parser[string] {
+	return RegexpRE2(reg)
+}
+
+func RegexpGroups(reg string) Parser[[]string] {
+	return RegexpGroupsRE2(reg)
+}
+
+func RegexpNamed(reg string) Parser[map[string]string] {
+	return RegexpNamedRE2(reg)
+}
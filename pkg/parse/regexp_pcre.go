@@ -0,0 +1,196 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+//go:build !nopcre
+
+package parse
+
+import (
+	"strings"
+
+	"github.com/gijsbers/go-pcre"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+// You better believe that we're using PCREs. It's the only
+// redeeming bit of Perl. (Unless we're built with the
+// `nopcre` tag, in which case see 'regexp_nopcre.go'.)
+func Regexp(reg string) Parser[string] {
+	return pcreParser(reg, func(m *pcre.Matcher) string {
+		return m.GroupString(0)
+	})
+}
+
+// The whole match (as for `Regexp`), followed by each of the
+// capturing groups, in order; any that didn't take part in
+// the match are "".
+func RegexpGroups(reg string) Parser[[]string] {
+	return pcreParser(reg, func(m *pcre.Matcher) []string {
+		gs := make([]string, m.Groups()+1)
+
+		for i := range gs {
+			if m.Present(i) {
+				gs[i] = m.GroupString(i)
+			}
+		}
+
+		return gs
+	})
+}
+
+// The named groups (`(?<name>...)`, etc.) that took part in
+// the match, by name.
+func RegexpNamed(reg string) Parser[map[string]string] {
+	names := groupNames(reg)
+
+	return pcreParser(reg, func(m *pcre.Matcher) map[string]string {
+		gs := map[string]string{}
+
+		for _, name := range names {
+			if ok, _ := m.NamedPresent(name); ok {
+				gs[name], _ = m.NamedString(name)
+			}
+		}
+
+		return gs
+	})
+}
+
+// Match `reg`, and make something of the match.
+func pcreParser[A any](reg string, f func(m *pcre.Matcher) A) Parser[A] {
+	// (The group keeps the anchor from applying to just the
+	// first alternative.)
+	r := pcre.MustCompile("^(?:"+reg+")", 0)
+	return makeParser(func(src *source) M[A] {
+		return Bind(
+			getSt(),
+			func(ix int) M[A] {
+				// (An empty match isn't a match for tokens.)
+				if !src.textQ() {
+					src.expected(ix, reg)
+
+					return fail[A]()
+				}
+
+				// Only as much of the input as the match
+				// needs (give or take), rather than all of
+				// the rest of it every time.
+				n := regexpChunk
+
+				m := r.MatcherString(src.regexpWindow(ix, n))
+				for m.Partial() {
+					n *= 2
+					m = r.MatcherString(src.regexpWindow(ix, n))
+				}
+
+				if !m.Matches() {
+					src.expected(ix, reg)
+
+					return fail[A]()
+				}
+
+				v, n := f(m), len(m.GroupString(0))
+				return Bind(
+					setSt(ix+n),
+					func(data.Unit) M[A] {
+						return Return(v)
+					},
+				)
+			},
+		)
+	})
+}
+
+// PCRE won't tell us what the groups are called, so we look
+// for `(?<name>`, `(?P<name>`, and `(?'name'` ourselves
+// (skipping escapes and character classes).
+func groupNames(reg string) []string {
+	var names []string
+
+	for i := 0; i < len(reg); i++ {
+		switch reg[i] {
+		case '\\':
+			i++
+		case '[':
+			i++
+			if i < len(reg) && reg[i] == '^' {
+				i++
+			}
+
+			// A leading ']' is part of the class.
+			if i < len(reg) && reg[i] == ']' {
+				i++
+			}
+
+			for i < len(reg) && reg[i] != ']' {
+				if reg[i] == '\\' {
+					i++
+				}
+
+				i++
+			}
+		case '(':
+			for _, open := range []string{"?<", "?P<", "?'"} {
+				if !strings.HasPrefix(reg[i+1:], open) {
+					continue
+				}
+
+				k := i + 1 + len(open)
+				j := k
+
+				for j < len(reg) && (reg[j] == '_' || 'a' <= reg[j] && reg[j] <= 'z' ||
+					'A' <= reg[j] && reg[j] <= 'Z' || '0' <= reg[j] && reg[j] <= '9') {
+					j++
+				}
+
+				// (Not, e.g., a lookbehind.)
+				close := byte('>')
+				if open == "?'" {
+					close = '\''
+				}
+
+				if j > k && j < len(reg) && reg[j] == close {
+					names = append(names, reg[k:j])
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// How much input a regexp match gets to start with.
+const regexpChunk = 1 << 10
+
+// Up to `n` bytes of the window from `ix` on (reading them,
+// if we're streaming), and the flags for matching them: if
+// the input might go on past them, a match that gets that far
+// should give up, so that we can try again with more.
+func (src *source) regexpWindow(ix, n int) (string, int) {
+	src.need(ix, ix+n)
+
+	s := src.window(ix)
+
+	switch {
+	case len(s) > n:
+		return s[:n], pcre.PARTIAL_HARD
+	case src.rd != nil:
+		return s, pcre.PARTIAL_HARD
+	default:
+		return s, 0
+	}
+}
@@ -0,0 +1,43 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+//go:build !nopcre
+
+package parse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupNames(t *testing.T) {
+	cases := []struct {
+		reg   string
+		names []string
+	}{
+		{`(?<a>x)(?P<b>y)(?'c'z)`, []string{"a", "b", "c"}},
+		{`(x)(?:y)(?<=a)(?<!b)(?=c)`, nil},
+		{`\(?<a>x\)`, nil},
+		{`[(?<a>]`, nil},
+		{`[](?<a>](?<b>x)`, []string{"b"}},
+		{`[\](?<a>](?<b_1>x)`, []string{"b_1"}},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.names, groupNames(c.reg), c.reg)
+	}
+}
@@ -0,0 +1,137 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"io"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+// `Regexp`, but with Go's own (RE2) regexps, which don't need
+// cgo (nor libpcre). The syntax is mostly the same, but
+// there are no backreferences or lookaround. The match is
+// anchored at the current offset.
+func RegexpRE2(reg string) Parser[string] {
+	return re2Parser(reg, false, func(_ *regexp.Regexp, loc []int, text func(int, int) string) string {
+		return text(loc[0], loc[1])
+	})
+}
+
+// `RegexpGroups`, with RE2.
+func RegexpGroupsRE2(reg string) Parser[[]string] {
+	return re2Parser(reg, true, func(_ *regexp.Regexp, loc []int, text func(int, int) string) []string {
+		gs := make([]string, len(loc)/2)
+
+		for i := range gs {
+			if loc[2*i] >= 0 {
+				gs[i] = text(loc[2*i], loc[2*i+1])
+			}
+		}
+
+		return gs
+	})
+}
+
+// `RegexpNamed`, with RE2.
+func RegexpNamedRE2(reg string) Parser[map[string]string] {
+	return re2Parser(reg, true, func(r *regexp.Regexp, loc []int, text func(int, int) string) map[string]string {
+		gs := map[string]string{}
+
+		for i, name := range r.SubexpNames() {
+			if name != "" && loc[2*i] >= 0 {
+				gs[name] = text(loc[2*i], loc[2*i+1])
+			}
+		}
+
+		return gs
+	})
+}
+
+// Match `reg` (finding the groups, too, if `sub`), and make
+// something of where (relative to the offset) it matched,
+// which `text` turns into the input.
+func re2Parser[A any](reg string, sub bool, f func(r *regexp.Regexp, loc []int, text func(int, int) string) A) Parser[A] {
+	r := regexp.MustCompile(`^(?:` + reg + `)`)
+
+	return makeParser(func(src *source) M[A] {
+		return Bind(
+			getSt(),
+			func(ix int) M[A] {
+				// (An empty match isn't a match for tokens.)
+				if !src.textQ() {
+					src.expected(ix, reg)
+
+					return fail[A]()
+				}
+
+				var loc []int
+
+				// When streaming, there's no telling how much
+				// input the match needs, so the matcher reads
+				// it as it goes.
+				switch {
+				case src.rd != nil && sub:
+					loc = r.FindReaderSubmatchIndex(&sourceRunes{src, ix, ix})
+				case src.rd != nil:
+					loc = r.FindReaderIndex(&sourceRunes{src, ix, ix})
+				case sub:
+					loc = r.FindStringSubmatchIndex(src.window(ix))
+				default:
+					loc = r.FindStringIndex(src.window(ix))
+				}
+
+				if loc == nil {
+					src.expected(ix, reg)
+
+					return fail[A]()
+				}
+
+				v := f(r, loc, func(i, j int) string { return src.slice(ix+i, ix+j) })
+				return Bind(
+					setSt(ix+loc[1]),
+					func(data.Unit) M[A] {
+						return Return(v)
+					},
+				)
+			},
+		)
+	})
+}
+
+// The input from `i` on, for a match starting at `ix` (which
+// we'd better not forget meanwhile).
+type sourceRunes struct {
+	src   *source
+	ix, i int
+}
+
+func (r *sourceRunes) ReadRune() (rune, int, error) {
+	r.src.need(r.ix, r.i+utf8.UTFMax)
+
+	s := r.src.window(r.i)
+	if s == "" {
+		return 0, 0, io.EOF
+	}
+
+	c, w := utf8.DecodeRuneInString(s)
+	r.i += w
+
+	return c, w, nil
+}
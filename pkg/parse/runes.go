@@ -0,0 +1,88 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+// Character-level parsers for UTF-8 input: These are the
+// counterparts of `OneOf`, `Chr`, and `NoneOf`, but they
+// decode a whole rune (and advance past all of its bytes).
+// Invalid UTF-8 never matches.
+
+func OneOfR(p func(rune) bool) Parser[rune] {
+	return oneOfR(p, "")
+}
+
+func ChrR(c rune) Parser[rune] {
+	desc := strconv.Quote(string(c))
+	first := &firstSet{
+		fail: func(src *source, ix int) {
+			src.expected(ix, desc)
+		},
+	}
+	first.bytes[string(c)[0]] = true
+
+	return withFirst(oneOfR(
+		func(cP rune) bool {
+			return c == cP
+		},
+		desc,
+	), first)
+}
+
+func NoneOfR(p func(rune) bool) Parser[rune] {
+	return OneOfR(func(c rune) bool {
+		return !p(c)
+	})
+}
+
+// Unlike with bytes, we can't just ask `p` about every
+// character to describe it, so it's up to the caller.
+func oneOfR(p func(rune) bool, what string) Parser[rune] {
+	return makeParser(
+		func(src *source) M[rune] {
+			return Bind(
+				getSt(),
+				func(ix int) M[rune] {
+					src.need(ix, ix+utf8.UTFMax)
+
+					if s := src.window(ix); s != "" {
+						c, w := utf8.DecodeRuneInString(s)
+
+						if !(c == utf8.RuneError && w <= 1) && p(c) {
+							return Bind(
+								setSt(ix+w),
+								func(data.Unit) M[rune] {
+									return Return(c)
+								},
+							)
+						}
+					}
+
+					src.expected(ix, what)
+
+					return fail[rune]()
+				},
+			)
+		},
+	)
+}
@@ -0,0 +1,131 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import "github.com/kdpross/GoParse/pkg/data"
+
+// `Seq`, but for more parsers at once, so that multi-part
+// rules needn't pick apart nested pairs; `ProcN` hands the
+// components straight to `f`.
+
+func Seq3[A, B, C any](p1 Parser[A], p2 Parser[B], p3 Parser[C]) Parser[data.Tuple3[A, B, C]] {
+	return Proc(
+		Seq(p1, Seq(p2, p3)),
+		func(p data.Pair[A, data.Pair[B, C]]) data.Tuple3[A, B, C] {
+			return data.MkTuple3(p.First(), p.Second().First(), p.Second().Second())
+		},
+	)
+}
+
+func Seq4[A, B, C, D any](p1 Parser[A], p2 Parser[B], p3 Parser[C], p4 Parser[D]) Parser[data.Tuple4[A, B, C, D]] {
+	return Proc(
+		Seq(p1, Seq3(p2, p3, p4)),
+		func(p data.Pair[A, data.Tuple3[B, C, D]]) data.Tuple4[A, B, C, D] {
+			return data.MkTuple4(p.First(), p.Second().First(), p.Second().Second(), p.Second().Third())
+		},
+	)
+}
+
+func Seq5[A, B, C, D, E any](p1 Parser[A], p2 Parser[B], p3 Parser[C], p4 Parser[D], p5 Parser[E]) Parser[data.Tuple5[A, B, C, D, E]] {
+	return Proc(
+		Seq(p1, Seq4(p2, p3, p4, p5)),
+		func(p data.Pair[A, data.Tuple4[B, C, D, E]]) data.Tuple5[A, B, C, D, E] {
+			return data.MkTuple5(p.First(), p.Second().First(), p.Second().Second(), p.Second().Third(), p.Second().Fourth())
+		},
+	)
+}
+
+func Seq6[A, B, C, D, E, F any](p1 Parser[A], p2 Parser[B], p3 Parser[C], p4 Parser[D], p5 Parser[E], p6 Parser[F]) Parser[data.Tuple6[A, B, C, D, E, F]] {
+	return Proc(
+		Seq(p1, Seq5(p2, p3, p4, p5, p6)),
+		func(p data.Pair[A, data.Tuple5[B, C, D, E, F]]) data.Tuple6[A, B, C, D, E, F] {
+			return data.MkTuple6(p.First(), p.Second().First(), p.Second().Second(), p.Second().Third(), p.Second().Fourth(), p.Second().Fifth())
+		},
+	)
+}
+
+func Seq7[A, B, C, D, E, F, G any](p1 Parser[A], p2 Parser[B], p3 Parser[C], p4 Parser[D], p5 Parser[E], p6 Parser[F], p7 Parser[G]) Parser[data.Tuple7[A, B, C, D, E, F, G]] {
+	return Proc(
+		Seq(p1, Seq6(p2, p3, p4, p5, p6, p7)),
+		func(p data.Pair[A, data.Tuple6[B, C, D, E, F, G]]) data.Tuple7[A, B, C, D, E, F, G] {
+			return data.MkTuple7(p.First(), p.Second().First(), p.Second().Second(), p.Second().Third(), p.Second().Fourth(), p.Second().Fifth(), p.Second().Sixth())
+		},
+	)
+}
+
+func Seq8[A, B, C, D, E, F, G, H any](p1 Parser[A], p2 Parser[B], p3 Parser[C], p4 Parser[D], p5 Parser[E], p6 Parser[F], p7 Parser[G], p8 Parser[H]) Parser[data.Tuple8[A, B, C, D, E, F, G, H]] {
+	return Proc(
+		Seq(p1, Seq7(p2, p3, p4, p5, p6, p7, p8)),
+		func(p data.Pair[A, data.Tuple7[B, C, D, E, F, G, H]]) data.Tuple8[A, B, C, D, E, F, G, H] {
+			return data.MkTuple8(p.First(), p.Second().First(), p.Second().Second(), p.Second().Third(), p.Second().Fourth(), p.Second().Fifth(), p.Second().Sixth(), p.Second().Seventh())
+		},
+	)
+}
+
+func Proc3[A, B, C, R any](p1 Parser[A], p2 Parser[B], p3 Parser[C], f func(A, B, C) R) Parser[R] {
+	return Proc(
+		Seq3(p1, p2, p3),
+		func(t data.Tuple3[A, B, C]) R {
+			return f(t.First(), t.Second(), t.Third())
+		},
+	)
+}
+
+func Proc4[A, B, C, D, R any](p1 Parser[A], p2 Parser[B], p3 Parser[C], p4 Parser[D], f func(A, B, C, D) R) Parser[R] {
+	return Proc(
+		Seq4(p1, p2, p3, p4),
+		func(t data.Tuple4[A, B, C, D]) R {
+			return f(t.First(), t.Second(), t.Third(), t.Fourth())
+		},
+	)
+}
+
+func Proc5[A, B, C, D, E, R any](p1 Parser[A], p2 Parser[B], p3 Parser[C], p4 Parser[D], p5 Parser[E], f func(A, B, C, D, E) R) Parser[R] {
+	return Proc(
+		Seq5(p1, p2, p3, p4, p5),
+		func(t data.Tuple5[A, B, C, D, E]) R {
+			return f(t.First(), t.Second(), t.Third(), t.Fourth(), t.Fifth())
+		},
+	)
+}
+
+func Proc6[A, B, C, D, E, F, R any](p1 Parser[A], p2 Parser[B], p3 Parser[C], p4 Parser[D], p5 Parser[E], p6 Parser[F], f func(A, B, C, D, E, F) R) Parser[R] {
+	return Proc(
+		Seq6(p1, p2, p3, p4, p5, p6),
+		func(t data.Tuple6[A, B, C, D, E, F]) R {
+			return f(t.First(), t.Second(), t.Third(), t.Fourth(), t.Fifth(), t.Sixth())
+		},
+	)
+}
+
+func Proc7[A, B, C, D, E, F, G, R any](p1 Parser[A], p2 Parser[B], p3 Parser[C], p4 Parser[D], p5 Parser[E], p6 Parser[F], p7 Parser[G], f func(A, B, C, D, E, F, G) R) Parser[R] {
+	return Proc(
+		Seq7(p1, p2, p3, p4, p5, p6, p7),
+		func(t data.Tuple7[A, B, C, D, E, F, G]) R {
+			return f(t.First(), t.Second(), t.Third(), t.Fourth(), t.Fifth(), t.Sixth(), t.Seventh())
+		},
+	)
+}
+
+func Proc8[A, B, C, D, E, F, G, H, R any](p1 Parser[A], p2 Parser[B], p3 Parser[C], p4 Parser[D], p5 Parser[E], p6 Parser[F], p7 Parser[G], p8 Parser[H], f func(A, B, C, D, E, F, G, H) R) Parser[R] {
+	return Proc(
+		Seq8(p1, p2, p3, p4, p5, p6, p7, p8),
+		func(t data.Tuple8[A, B, C, D, E, F, G, H]) R {
+			return f(t.First(), t.Second(), t.Third(), t.Fourth(), t.Fifth(), t.Sixth(), t.Seventh(), t.Eighth())
+		},
+	)
+}
@@ -0,0 +1,102 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+func TestSeq3(t *testing.T) {
+	num := Proc(Txt("1"), func(s string) int {
+		n, _ := strconv.Atoi(s)
+
+		return n
+	})
+	p := Seq3(Txt("a"), num, Chr('c'))
+
+	r := Parse(p, "a1c")
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+
+	assert.Equal(t, data.MkTuple3("a", 1, byte('c')), v)
+	assert.True(t, Parse(p, "a1").FailureQ())
+	assert.True(t, Parse(p, "a2c").FailureQ())
+}
+
+func TestSeq8(t *testing.T) {
+	p := Seq8(Txt("a"), Txt("b"), Txt("c"), Txt("d"), Txt("e"), Txt("f"), Txt("g"), Txt("h"))
+
+	r := Parse(p, "abcdefgh")
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+
+	assert.Equal(t, data.MkTuple8("a", "b", "c", "d", "e", "f", "g", "h"), v)
+
+	e := Parse(p, "abcdefgx").GetFailure()
+
+	assert.Equal(t, 7, e.Offset)
+	assert.Equal(t, []string{`"h"`}, e.Expected)
+}
+
+func TestProcN(t *testing.T) {
+	cat := func(ss ...string) string {
+		res := ""
+		for _, s := range ss {
+			res += s
+		}
+
+		return res
+	}
+
+	for _, c := range []struct {
+		p Parser[string]
+		s string
+	}{
+		{Proc3(Txt("a"), Txt("b"), Txt("c"), func(a, b, c string) string { return cat(a, b, c) }), "abc"},
+		{Proc4(Txt("a"), Txt("b"), Txt("c"), Txt("d"), func(a, b, c, d string) string { return cat(a, b, c, d) }), "abcd"},
+		{Proc5(Txt("a"), Txt("b"), Txt("c"), Txt("d"), Txt("e"), func(a, b, c, d, e string) string {
+			return cat(a, b, c, d, e)
+		}), "abcde"},
+		{Proc6(Txt("a"), Txt("b"), Txt("c"), Txt("d"), Txt("e"), Txt("f"), func(a, b, c, d, e, f string) string {
+			return cat(a, b, c, d, e, f)
+		}), "abcdef"},
+		{Proc7(Txt("a"), Txt("b"), Txt("c"), Txt("d"), Txt("e"), Txt("f"), Txt("g"), func(a, b, c, d, e, f, g string) string {
+			return cat(a, b, c, d, e, f, g)
+		}), "abcdefg"},
+		{Proc8(Txt("a"), Txt("b"), Txt("c"), Txt("d"), Txt("e"), Txt("f"), Txt("g"), Txt("h"), func(a, b, c, d, e, f, g, h string) string {
+			return cat(a, b, c, d, e, f, g, h)
+		}), "abcdefgh"},
+	} {
+		r := Parse(c.p, c.s)
+
+		require.True(t, r.SuccessQ(), c.s)
+
+		v, _ := r.GetSuccess()
+
+		assert.Equal(t, c.s, v)
+	}
+}
@@ -0,0 +1,111 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+// Where a parsed value came from: `Start` and `End` are
+// offsets (as for `GetSuccess`), and `Line` and `Col` are
+// the position of `Start` (as for `ParseError`, so they're
+// 0 for tokens).
+type Span struct {
+	Start int
+	End   int
+	Line  int
+	Col   int
+}
+
+// Pair `p`'s value with where it was found.
+func WithSpan[A any](p Parser[A]) Parser[data.Pair[A, Span]] {
+	return withFirst(makeParser(
+		func(src *source) M[data.Pair[A, Span]] {
+			return M[data.Pair[A, Span]]{
+				func(ix int) Result[data.Pair[A, Span]] {
+					// While we've still got the start (which
+					// `ParseReader` may have forgotten by the
+					// end).
+					line, col := src.lineCol(ix)
+
+					r := p.core(src).f(ix)
+					if r.FailureQ() {
+						return failure[data.Pair[A, Span]]{}
+					}
+
+					v, ixP := r.GetSuccess()
+
+					return success[data.Pair[A, Span]]{
+						data.MkPair(v, Span{ix, ixP, line, col}),
+						ixP,
+					}
+				},
+			}
+		},
+	), p.first)
+}
+
+// Like `Proc`, but `f` gets to know where its input was,
+// e.g., to record it in an AST.
+func ProcSpan[A, B any](p Parser[A], f func(A, Span) B) Parser[B] {
+	return Proc(
+		WithSpan(p),
+		func(p data.Pair[A, Span]) B {
+			return f(p.First(), p.Second())
+		},
+	)
+}
+
+// The input that `p` matched, along with its value. This is
+// just a substring of the input (so there's no copying),
+// which, e.g., keeps the original spelling of a number. (For
+// tokens, it's empty.)
+func Consumed[A any](p Parser[A]) Parser[data.Pair[A, string]] {
+	return withFirst(makeParser(
+		func(src *source) M[data.Pair[A, string]] {
+			return M[data.Pair[A, string]]{
+				func(ix int) Result[data.Pair[A, string]] {
+					// `ParseReader` mustn't forget the start.
+					src.pin(ix)
+					r := p.core(src).f(ix)
+					src.unpin()
+
+					if r.FailureQ() {
+						return failure[data.Pair[A, string]]{}
+					}
+
+					v, ixP := r.GetSuccess()
+
+					return success[data.Pair[A, string]]{
+						data.MkPair(v, src.slice(ix, ixP)),
+						ixP,
+					}
+				},
+			}
+		},
+	), p.first)
+}
+
+// Just the input that `p` matched (see `Consumed`).
+func Recognize[A any](p Parser[A]) Parser[string] {
+	return Proc(
+		Consumed(p),
+		func(p data.Pair[A, string]) string {
+			return p.Second()
+		},
+	)
+}
@@ -0,0 +1,189 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+var wordSpans = SeqLeft(
+	Rep(ProcSpan(
+		SeqLeft(Regexp("[a-zé]+"), Regexp("[ \n]*")),
+		func(_ string, sp Span) Span { return sp },
+	)),
+	Eof(),
+)
+
+func TestSpan(t *testing.T) {
+	r := Parse(wordSpans, "ab cd\n\néf g\nh")
+
+	require.True(t, r.SuccessQ())
+
+	sps, _ := r.GetSuccess()
+	assert.Equal(
+		t,
+		[]Span{
+			{0, 3, 1, 1},
+			{3, 7, 1, 4},
+			{7, 11, 3, 1},
+			{11, 13, 3, 4},
+			{13, 14, 4, 1},
+		},
+		sps,
+	)
+}
+
+// One long line (as for minified JSON), with some going back
+// over it, shouldn't cost the length of the line per span.
+func TestSpanLongLine(t *testing.T) {
+	s := strings.Repeat("ab é ", 50_000)
+	word := Regexp("[a-zé]+ ")
+	p := SeqLeft(
+		Rep(Alt(
+			ProcSpan(SeqLeft(word, Txt("!")), func(_ string, sp Span) Span { return sp }),
+			ProcSpan(word, func(_ string, sp Span) Span { return sp }),
+		)),
+		Eof(),
+	)
+
+	r := Parse(p, s)
+
+	require.True(t, r.SuccessQ())
+
+	sps, _ := r.GetSuccess()
+	require.Len(t, sps, 100_000)
+
+	col := 1
+
+	for i, sp := range sps {
+		assert.Equal(t, 1, sp.Line)
+		require.Equal(t, col, sp.Col, "span %d", i)
+
+		col += utf8.RuneCountInString(s[sp.Start:sp.End])
+	}
+}
+
+// Where an error is can't depend on which positions were
+// asked for before it, even in the middle of a character.
+func TestSpanMidRune(t *testing.T) {
+	anyB := OneOf(func(byte) bool { return true })
+	spanB := Proc(WithSpan(anyB), func(p data.Pair[byte, Span]) byte { return p.First() })
+
+	e1 := Parse(Seq(anyB, Seq(anyB, Txt("x"))), "éé").GetFailure()
+	e2 := Parse(Seq(anyB, Seq(spanB, Txt("x"))), "éé").GetFailure()
+
+	assert.Equal(t, 2, e1.Offset)
+	assert.Equal(t, e1.Col, e2.Col)
+	assert.Equal(t, 2, e2.Col)
+}
+
+func TestSpanReader(t *testing.T) {
+	s := strings.Repeat("abc dé\nf\n", 20_000)
+
+	exp := Parse(wordSpans, s)
+
+	require.True(t, exp.SuccessQ())
+
+	r, err := ParseReader(wordSpans, iotest.HalfReader(strings.NewReader(s)))
+	require.NoError(t, err)
+	require.True(t, r.SuccessQ())
+
+	sps, _ := r.GetSuccess()
+	spsExp, _ := exp.GetSuccess()
+	assert.Equal(t, spsExp, sps)
+	assert.Equal(t, Span{len(s) - 2, len(s), 40_000, 1}, sps[len(sps)-1])
+}
+
+func TestSpanTokens(t *testing.T) {
+	p := WithSpan(Rep(Satisfy(func(int) bool { return true })))
+
+	r := ParseTokens(p, []int{1, 2, 3})
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, Span{0, 3, 0, 0}, v.Second())
+}
+
+func TestConsumed(t *testing.T) {
+	num := Proc(Regexp("0*[0-9]+"), func(s string) int {
+		n := 0
+		for _, c := range s {
+			n = 10*n + int(c-'0')
+		}
+
+		return n
+	})
+
+	s := "x = 007;"
+	r := Parse(SeqRight(Txt("x = "), SeqLeft(Consumed(num), Txt(";"))), s)
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, data.MkPair(7, "007"), v)
+
+	// It's the input itself, not a copy.
+	assert.Equal(t, unsafe.Add(unsafe.Pointer(unsafe.StringData(s)), 4), unsafe.Pointer(unsafe.StringData(v.Second())))
+}
+
+func TestRecognize(t *testing.T) {
+	p := Recognize(Rep(Alt(Txt("ab"), Proc(Chr('c'), func(byte) string { return "" }))))
+
+	r := Parse(p, "abcab!")
+
+	require.True(t, r.SuccessQ())
+
+	v, ix := r.GetSuccess()
+	assert.Equal(t, "abcab", v)
+	assert.Equal(t, 5, ix)
+
+	assert.True(t, Parse(Recognize(Txt("x")), "y").FailureQ())
+}
+
+func TestRecognizeReader(t *testing.T) {
+	// The cut would let `ParseReader` forget the start, but
+	// for `Recognize`.
+	stmt := Recognize(SeqRight(Seq(Txt("let"), Cut()), Seq(Regexp(" x*"), Txt(";"))))
+	s := "let " + strings.Repeat("x", 3*readChunk) + ";"
+
+	r, err := ParseReader(stmt, iotest.HalfReader(strings.NewReader(s)))
+	require.NoError(t, err)
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, s, v)
+}
+
+func TestRecognizeTokens(t *testing.T) {
+	r := ParseTokens(Recognize(Satisfy(func(int) bool { return true })), []int{1})
+
+	require.True(t, r.SuccessQ())
+
+	v, ix := r.GetSuccess()
+	assert.Equal(t, "", v)
+	assert.Equal(t, 1, ix)
+}
@@ -0,0 +1,93 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import "github.com/kdpross/GoParse/pkg/data"
+
+// Some grammars need to remember things as they go, e.g., C's
+// typedef names (which then parse differently) or how deeply
+// nested we are. So, there's a slot for a user-defined state
+// in each parse; it's the zero value of its type to begin
+// with (unless it's given to `ParseWithState`), and it's
+// threaded through the parse like the offset is: Whatever
+// backtracks (`Alt`, `Rep`, `LookAhead`, ...) puts it back
+// as it was, and a `Cache`d result only stands for the state
+// that it was worked out in.
+//
+// For that to work, the state must be treated as a *value*:
+// `ModifyState` should make a new one (e.g., copy a map
+// before adding to it) rather than changing the old one,
+// which is what we'd be backing up to.
+//
+// (As `Cache` can't tell when the state has changed, rules
+// mustn't change it before (left-)recursing into themselves
+// at the same offset.)
+
+type userState struct {
+	v any
+	// Which `PutState` this came from, to tell states apart
+	// without comparing them (which `any` can't).
+	gen uint64
+}
+
+func (src *source) putState(v any) {
+	src.gens++
+	src.state = userState{v, src.gens}
+}
+
+func GetState[S any]() Parser[S] {
+	return makeParser(
+		func(src *source) M[S] {
+			return M[S]{
+				func(ix int) Result[S] {
+					// Nil means that it hasn't been set yet.
+					v, _ := src.state.v.(S)
+
+					return success[S]{v, ix}
+				},
+			}
+		},
+	)
+}
+
+func PutState[S any](st S) Parser[data.Unit] {
+	return makeParser(
+		func(src *source) M[data.Unit] {
+			return M[data.Unit]{
+				func(ix int) Result[data.Unit] {
+					src.putState(st)
+
+					return success[data.Unit]{data.Unit{}, ix}
+				},
+			}
+		},
+	)
+}
+
+func ModifyState[S any](f func(S) S) Parser[data.Unit] {
+	return Then(GetState[S](), func(st S) Parser[data.Unit] {
+		return PutState(f(st))
+	})
+}
+
+// `Parse`, starting with the state `st`.
+func ParseWithState[S, A any](p Parser[A], s string, st S) Result[A] {
+	src := newSource(s)
+	src.putState(st)
+
+	return run(p, src)
+}
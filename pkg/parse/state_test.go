@@ -0,0 +1,219 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+func incr(n int) int {
+	return n + 1
+}
+
+func TestState(t *testing.T) {
+	p := SeqRight(Rep(Seq(Txt("a"), ModifyState(incr))), GetState[int]())
+
+	for _, c := range []struct {
+		s   string
+		exp int
+	}{
+		{"", 0},
+		{"a", 1},
+		{"aaaa", 4},
+	} {
+		r := Parse(p, c.s)
+
+		require.True(t, r.SuccessQ())
+
+		v, _ := r.GetSuccess()
+		assert.Equal(t, c.exp, v, c.s)
+	}
+
+	r := ParseWithState(p, "aa", 40)
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, 42, v)
+}
+
+// Backing up puts the state back, too.
+func TestStateBacktracking(t *testing.T) {
+	for _, c := range []struct {
+		lab string
+		p   Parser[int]
+		s   string
+		exp int
+	}{
+		{
+			"alt",
+			Alt(SeqRight(PutState(1), SeqRight(Txt("x"), GetState[int]())), GetState[int]()),
+			"y",
+			0,
+		},
+		{
+			"choice",
+			Choice(SeqRight(ModifyState(incr), SeqRight(Txt("x"), GetState[int]())), SeqRight(Txt("y"), GetState[int]())),
+			"y",
+			0,
+		},
+		{
+			"rep",
+			SeqRight(Rep(Seq(ModifyState(incr), Txt("a"))), GetState[int]()),
+			"aab",
+			2,
+		},
+		{
+			"look ahead",
+			SeqRight(LookAhead(Seq(ModifyState(incr), Txt("a"))), GetState[int]()),
+			"a",
+			0,
+		},
+		{
+			"not followed by",
+			SeqRight(NotFollowedBy(Seq(ModifyState(incr), Txt("b"))), GetState[int]()),
+			"a",
+			0,
+		},
+		{
+			"recover",
+			SeqRight(Recover(Seq(ModifyState(incr), Txt("a")), Chr(';'), data.Pair[data.Unit, string]{}), GetState[int]()),
+			"b;",
+			0,
+		},
+	} {
+		t.Run(c.lab, func(t *testing.T) {
+			r := Parse(c.p, c.s)
+
+			require.True(t, r.SuccessQ())
+
+			v, _ := r.GetSuccess()
+			assert.Equal(t, c.exp, v)
+		})
+	}
+}
+
+// A cached result is only good for the state that it was
+// worked out in, and recalling it gets us the state that it
+// finished in.
+func TestStateCache(t *testing.T) {
+	var rule data.Lazy[Parser[int]]
+	rule = data.MkLazy(func() Parser[int] {
+		return SeqLeft(SeqRight(ModifyState(func(n int) int { return n * 10 }), GetState[int]()), Txt("a"))
+	})
+
+	p := Alt(
+		SeqRight(PutState(1), Seq(SeqLeft(Cache(rule), Txt("!")), GetState[int]())),
+		SeqRight(PutState(2), Seq(Cache(rule), GetState[int]())),
+	)
+
+	r := Parse(p, "a")
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, data.MkPair(20, 20), v)
+
+	q := Alt(
+		SeqRight(Seq(Cache(rule), Txt("!")), GetState[int]()),
+		SeqRight(Cache(rule), GetState[int]()),
+	)
+
+	r2 := ParseWithState(q, "a", 3)
+
+	require.True(t, r2.SuccessQ())
+
+	v2, _ := r2.GetSuccess()
+	assert.Equal(t, 30, v2)
+}
+
+// Counting the terms as we go, through a left-recursive rule:
+// Each time the seed grows, it starts over from the state
+// that it started in.
+func TestStateLeftRecursion(t *testing.T) {
+	num := SeqLeft(Regexp("[0-9]+"), ModifyState(incr))
+
+	var sum data.Lazy[Parser[string]]
+	sum = data.MkLazy(func() Parser[string] {
+		return Alt(
+			Proc(
+				Seq(SeqLeft(Cache(sum), Txt("+")), num),
+				func(p data.Pair[string, string]) string {
+					return "(" + p.First() + "+" + p.Second() + ")"
+				},
+			),
+			num,
+		)
+	})
+
+	p := Seq(SeqLeft(Cache(sum), Eof()), GetState[int]())
+
+	r := Parse(p, "1+2+3")
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, data.MkPair("((1+2)+3)", 3), v)
+}
+
+// The motivating example: In C, `x * y;` declares `y` if `x`
+// is a typedef name and multiplies otherwise.
+func TestStateTypedefs(t *testing.T) {
+	type names map[string]bool
+
+	ident := Regexp("[a-z]+")
+	typeName := Then(GetState[names](), func(ns names) Parser[string] {
+		return Guard(ident, func(s string) bool { return ns[s] })
+	})
+
+	typedef := Proc(
+		SeqRight(Txt("typedef "), Then(ident, func(s string) Parser[data.Unit] {
+			return ModifyState(func(ns names) names {
+				nsP := maps.Clone(ns)
+				if nsP == nil {
+					nsP = names{}
+				}
+
+				nsP[s] = true
+
+				return nsP
+			})
+		})),
+		func(data.Unit) string { return "typedef" },
+	)
+	decl := Proc(Seq(SeqLeft(typeName, Txt(" * ")), ident), func(p data.Pair[string, string]) string {
+		return "decl " + p.Second()
+	})
+	mul := Proc(Seq(SeqLeft(ident, Txt(" * ")), ident), func(p data.Pair[string, string]) string {
+		return "mul"
+	})
+
+	p := SeqLeft(Rep(SeqLeft(Choice(typedef, decl, mul), Txt(";"))), Eof())
+
+	r := Parse(p, "x * y;typedef x;x * y;")
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, []string{"mul", "typedef", "decl y"}, v)
+}
@@ -0,0 +1,276 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Streaming: `ParseReader` reads its input a chunk at a time
+// and forgets whatever it can no longer need. That's
+// everything before the earliest offset that the parse might
+// yet back up to, i.e., the start of the outermost pending
+// alternative (the first branch of an `Alt`, the current
+// iteration of a `Rep`, a `Cache` that may still have a
+// left-recursive seed to grow, ...); these 'mark' their
+// offsets while they're pending. With nothing marked, it's
+// everything before wherever we're reading; nor can we back
+// up past a `Cut`. The forgetting happens just before
+// reading more, and takes the memo entries along with the
+// input.
+//
+// So the memory for the input is bounded by how far the
+// parser can back up, not by the size of the input:
+// `Rep(line)` holds on to one line of it (give or take a
+// chunk) at a time. Conversely, anything that's pending
+// throughout (e.g., an `Alt` or a `Cache` around the whole
+// grammar) pins the lot. Note that none of this goes for the
+// results: `Rep(line)` still collects every line's value.
+//
+// All of this is moot for `Parse`, whose window is simply
+// the whole input.
+
+const readChunk = 64 << 10
+
+// Like `Parse`, but reading the input from `r` as it's
+// needed. Any error from `r` (other than `io.EOF`) is
+// returned as well; as far as `p` is concerned, the input
+// just ends there. Note that `Peek` only gets to see the
+// window (with offsets relative to it).
+func ParseReader[A any](p Parser[A], r io.Reader) (Result[A], error) {
+	src := newSource("")
+	src.rd = r
+
+	res := run(p, src)
+
+	return res, src.rdErr
+}
+
+func (src *source) mark(ix int) {
+	src.marks = append(src.marks, ix)
+}
+
+func (src *source) unmark() {
+	src.marks = src.marks[:len(src.marks)-1]
+}
+
+// For things that can't go even if they've been cut, e.g.,
+// where a `Cache` may need to re-run a rule (to grow a
+// left-recursive seed).
+func (src *source) pin(ix int) {
+	src.pins = append(src.pins, ix)
+}
+
+func (src *source) unpin() {
+	src.pins = src.pins[:len(src.pins)-1]
+}
+
+// The earliest offset that we might still need, reading from
+// `ix`. The marks (and pins) are in increasing order:
+// Anything marked later is inside (and so after) whatever was
+// marked earlier. Marks before the last cut don't count.
+func (src *source) floor(ix int) int {
+	if i, _ := slices.BinarySearch(src.marks, src.cutIx); i < len(src.marks) {
+		ix = min(ix, src.marks[i])
+	}
+
+	if len(src.pins) > 0 {
+		ix = min(ix, src.pins[0])
+	}
+
+	return ix
+}
+
+// Make sure that the input up to `upto` is in the window (if
+// there's that much input at all); `ix` is where we're
+// reading from.
+func (src *source) need(ix, upto int) bool {
+	for src.base+len(src.str) < upto {
+		if !src.more(ix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// The window from `ix` on.
+func (src *source) window(ix int) string {
+	return src.str[min(ix-src.base, len(src.str)):]
+}
+
+// The input from `ix` to `ixP` (which we've already read);
+// there's no text to speak of for tokens.
+func (src *source) slice(ix, ixP int) string {
+	if src.toks != nil {
+		return ""
+	}
+
+	return src.str[ix-src.base : ixP-src.base]
+}
+
+func (src *source) byteAt(ix int) (byte, bool) {
+	if !src.need(ix, ix+1) {
+		return 0, false
+	}
+
+	return src.str[ix-src.base], true
+}
+
+func (src *source) atEnd(ix int) bool {
+	if src.toks != nil {
+		return ix == src.n
+	}
+
+	return !src.need(ix, ix+1)
+}
+
+func (src *source) more(ix int) bool {
+	if src.rd == nil {
+		return false
+	}
+
+	src.commit(src.floor(ix))
+
+	// Reading (at least) as much as we have keeps the copying
+	// linear if the window keeps growing.
+	buf := make([]byte, max(src.chunk, len(src.str)))
+
+	// A whole buffer-full at a time, so that we're not copying
+	// the window for every line that `Read` hands us.
+	n, err := io.ReadFull(src.rd, buf)
+	if n > 0 {
+		src.str += string(buf[:n])
+	}
+
+	switch {
+	case err == io.EOF || err == io.ErrUnexpectedEOF:
+		src.rd = nil
+	case err != nil:
+		src.rd, src.rdErr = nil, err
+	}
+
+	src.maxWindow = max(src.maxWindow, len(src.str))
+
+	return n > 0
+}
+
+// Forget everything before `ix`.
+func (src *source) commit(ix int) {
+	k := min(ix-src.base, len(src.str))
+	if k <= 0 {
+		return
+	}
+
+	// The farthest failure is the only offset that we might
+	// still need a position for, so work it out while we can.
+	if src.errIx >= src.base && src.errIx < ix {
+		src.errLine, src.errCol = src.position(src.errIx)
+	}
+
+	src.scanLines(ix)
+
+	if i, _ := slices.BinarySearch(src.nls, ix); i > 0 {
+		src.line0 += i
+		src.col0 = utf8.RuneCountInString(src.str[src.nls[i-1]+1-src.base : k])
+		src.nls = slices.Clone(src.nls[i:])
+	} else {
+		src.col0 += utf8.RuneCountInString(src.str[:k])
+	}
+
+	src.str, src.base = src.str[k:], src.base+k
+
+	src.forget(src.base)
+}
+
+// Forget the memo entries before `ix`.
+func (src *source) forget(ix int) {
+	memo := 0
+
+	for _, t := range src.memo {
+		memo += t.size()
+		t.prune(ix)
+	}
+
+	src.maxMemo = max(src.maxMemo, memo)
+}
+
+// Line and column of `ix`, allowing for whatever's been
+// forgotten.
+func (src *source) position(ix int) (int, int) {
+	if ix < src.base {
+		return src.errLine, src.errCol
+	}
+
+	ix = min(ix, src.base+len(src.str))
+
+	src.scanLines(ix)
+
+	i, _ := slices.BinarySearch(src.nls, ix)
+
+	// Where the line starts (or the window, if that's later).
+	start, col := src.base, src.col0+1
+	if i > 0 {
+		start, col = src.nls[i-1]+1, 1
+	}
+
+	// Counting from the last position that we worked out, if
+	// it's on the same line (and nearer), keeps a long line
+	// from costing us its length every time. (Only between
+	// characters, though: counting from the middle of one
+	// would come out differently.)
+	line := src.line0 + i + 1
+
+	switch {
+	case src.posLine != line || src.posIx < start || !src.runeStartQ(src.posIx) || !src.runeStartQ(ix):
+		col += utf8.RuneCountInString(src.str[start-src.base : ix-src.base])
+	case src.posIx <= ix:
+		col = src.posCol + utf8.RuneCountInString(src.str[src.posIx-src.base:ix-src.base])
+	case src.posIx-ix < ix-start:
+		col = src.posCol - utf8.RuneCountInString(src.str[ix-src.base:src.posIx-src.base])
+	default:
+		col += utf8.RuneCountInString(src.str[start-src.base : ix-src.base])
+	}
+
+	src.posIx, src.posLine, src.posCol = ix, line, col
+
+	return line, col
+}
+
+// Whether `ix` (in the window) is where a character starts.
+func (src *source) runeStartQ(ix int) bool {
+	return ix-src.base == len(src.str) || utf8.RuneStart(src.str[ix-src.base])
+}
+
+// Note the newlines up to `ix`, so that `position` needn't
+// count them every time.
+func (src *source) scanLines(ix int) {
+	for src.scanned < ix {
+		nl := strings.IndexByte(src.str[src.scanned-src.base:ix-src.base], '\n')
+		if nl < 0 {
+			src.scanned = ix
+
+			break
+		}
+
+		src.nls = append(src.nls, src.scanned+nl)
+		src.scanned += nl + 1
+	}
+}
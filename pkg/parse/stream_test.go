@@ -0,0 +1,222 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+// Produces `n` numbered lines without ever holding more than
+// one of them.
+type lineReader struct {
+	n, i int
+	line string
+}
+
+func (r *lineReader) Read(bs []byte) (int, error) {
+	if r.line == "" {
+		if r.i == r.n {
+			return 0, io.EOF
+		}
+
+		r.i++
+		r.line = strings.Repeat("x", r.i%50) + "=" + strings.Repeat("7", r.i%7+1) + "\n"
+	}
+
+	k := copy(bs, r.line)
+	r.line = r.line[k:]
+
+	return k, nil
+}
+
+type kv = data.Pair[string, string]
+
+var kvLine = Cache(data.MkLazy(func() Parser[kv] {
+	return SeqLeft(
+		Seq(SeqLeft(Regexp("x*"), Txt("=")), Alt(Regexp("[0-9]+"), Txt("-"))),
+		Chr('\n'),
+	)
+}))
+
+func TestParseReaderAgrees(t *testing.T) {
+	var expr data.Lazy[Parser[string]]
+	expr = data.MkLazy(func() Parser[string] {
+		return Alt(
+			Proc(Seq(SeqLeft(Cache(expr), Txt("-")), numP), binOp("-")),
+			numP,
+		)
+	})
+
+	join := func(vs []string) string { return strings.Join(vs, ",") }
+	setting := Proc(
+		SeqLeft(Seq(SeqLeft(Regexp("[a-z]+"), Txt("=")), numP), Chr('\n')),
+		func(p data.Pair[string, string]) string { return p.First() + "=" + p.Second() },
+	)
+	settings := strings.Repeat("abc=1\nde=x\nfgh=22\n", 5_000)
+
+	for _, c := range []struct {
+		lab string
+		p   Parser[string]
+		s   string
+	}{
+		{"txt", Proc(Rep(Alt(Txt("abc"), Txt("abd"))), join), "abcabdabcabx"},
+		{"alt", Alt(Txt("abcdefg!"), Txt("abcdefgh")), "abcdefgh"},
+		{"regexp", Regexp("[0-9]+"), "1234567890x"},
+		{"regexp re2", RegexpRE2("[0-9]+"), "1234567890x"},
+		{"regexp re2 runes", RegexpRE2("[aé€]*"), "aé€😀b"},
+		{"regexp re2 failure", RegexpRE2("[0-9]+x"), "1234567890y"},
+		{"runes", Proc(Rep(OneOfR(func(rune) bool { return true })), func(cs []rune) string { return string(cs) }), "aé€😀b"},
+		{"left recursion", Cache(expr), "12-3-45-6"},
+		{"eof", SeqLeft(Cache(expr), Eof()), "12-3-45-"},
+		{"lines", Proc(SeqLeft(Rep(Txt("ab\n")), Eof()), func([]string) string { return "" }), "ab\nab\nab\nac\n"},
+		{"consumed", Recognize(Rep(Alt(Txt("ab"), Txt("ac")))), "abacabacad"},
+		{"recover", Proc(SeqLeft(Rep(Recover(setting, Chr('\n'), "?")), Eof()), join), settings},
+		{"recover failure", Proc(SeqLeft(Rep(Recover(setting, Chr('\n'), "?")), Eof()), join), settings + "!"},
+		{"long regexps", Proc(SeqLeft(Rep(SeqLeft(Regexp("[a-z]+=[0-9x]+"), Chr('\n'))), Eof()), join), settings},
+	} {
+		// Small chunks make for a lot of boundaries (and so a
+		// lot of forgetting); the real size, for inputs that
+		// are longer than it, for a few.
+		for _, k := range []int{1, 3, 7, readChunk} {
+			t.Run(fmt.Sprintf("%s/%d", c.lab, k), func(t *testing.T) {
+				exp := Parse(c.p, c.s)
+
+				src := newSource("")
+				src.rd, src.chunk = strings.NewReader(c.s), k
+
+				r := run(c.p, src)
+				require.NoError(t, src.rdErr)
+
+				require.Equal(t, exp.SuccessQ(), r.SuccessQ())
+
+				if exp.SuccessQ() {
+					v, ix := r.GetSuccess()
+					vExp, ixExp := exp.GetSuccess()
+					assert.Equal(t, vExp, v)
+					assert.Equal(t, ixExp, ix)
+				} else {
+					assert.Equal(t, exp.GetFailure(), r.GetFailure())
+				}
+
+				assert.Equal(t, exp.GetErrors(), r.GetErrors())
+
+				// (And we did get to forget things.)
+				if k < readChunk && len(c.s) > readChunk {
+					assert.Less(t, src.maxWindow, readChunk)
+				}
+			})
+		}
+	}
+
+	require.Greater(t, len(settings), readChunk)
+}
+
+func TestParseReaderBounded(t *testing.T) {
+	n := 100_000
+	src := newSource("")
+	src.rd = &lineReader{n: n}
+
+	r := run(SeqLeft(Rep(kvLine), Eof()), src)
+
+	require.True(t, r.SuccessQ())
+
+	vs, ix := r.GetSuccess()
+	assert.Len(t, vs, n)
+	assert.Greater(t, ix, 6*readChunk)
+
+	// A line, plus a chunk.
+	assert.Less(t, src.maxWindow, 2*readChunk)
+	assert.Less(t, src.maxMemo, 2*readChunk)
+}
+
+// An `Alt` around everything would pin everything, but not
+// once it's been cut.
+func TestParseReaderCut(t *testing.T) {
+	n := 100_000
+	src := newSource("")
+	src.rd = &lineReader{n: n}
+
+	p := Alt(SeqLeft(Rep(SeqLeft(kvLine, Cut())), Eof()), ParserFail[[]kv]("no"))
+
+	r := run(p, src)
+
+	require.True(t, r.SuccessQ())
+	assert.Less(t, src.maxWindow, 2*readChunk)
+}
+
+func TestParseReaderPosition(t *testing.T) {
+	n := 20_000
+	bad := (&lineReader{n: n}).String() + "x=y\n"
+
+	r, err := ParseReader(SeqLeft(Rep(kvLine), Eof()), strings.NewReader(bad))
+	require.NoError(t, err)
+	require.True(t, r.FailureQ())
+
+	e := r.GetFailure()
+	assert.Equal(t, n+1, e.Line)
+	assert.Equal(t, 3, e.Col)
+	assert.Equal(t, Parse(SeqLeft(Rep(kvLine), Eof()), bad).GetFailure(), e)
+}
+
+func TestParseReaderError(t *testing.T) {
+	boom := errors.New("boom")
+	rd := io.MultiReader(strings.NewReader("x=1\nx="), iotest.ErrReader(boom))
+
+	r, err := ParseReader(Rep(kvLine), rd)
+
+	assert.ErrorIs(t, err, boom)
+	require.True(t, r.SuccessQ())
+
+	vs, _ := r.GetSuccess()
+	assert.Len(t, vs, 1)
+}
+
+// `Recover` (not inside a `Rep`) has to hang on to where `p`
+// started, even once `p` has read past a chunk or two.
+func TestParseReaderRecover(t *testing.T) {
+	long := strings.Repeat("x", 2*readChunk)
+	p := SeqLeft(Recover(Txt(long+"Q"), Txt("\n"), "fb"), Eof())
+
+	r, err := ParseReader(p, strings.NewReader(long+"Z\n"))
+	require.NoError(t, err)
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, "fb", v)
+
+	es := r.GetErrors()
+	require.Len(t, es, 1)
+	assert.Equal(t, 0, es[0].Offset)
+}
+
+func (r *lineReader) String() string {
+	var sb strings.Builder
+
+	_, _ = io.Copy(&sb, r)
+
+	return sb.String()
+}
@@ -0,0 +1,101 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"fmt"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+// Parsing isn't only for text: We can just as well run a
+// lexer (itself written with this library, perhaps) and then
+// parse the resulting tokens. Ideally, the input type would
+// be a type parameter of `Parser`, but that would make
+// *every* signature in sight more verbose for the sake of
+// the less-common case. Instead, the input type is only
+// checked when a token is actually looked at.
+//
+// The combinators (`Seq`, `Alt`, `Cache`, `Eof`, ...) are
+// indifferent to what they're parsing; text-based parsers
+// (`Txt`, `OneOf`, `Regexp`, ...) never match tokens (not
+// even `Txt("")`), and token-based parsers (`Satisfy`) fail
+// on text, or on the wrong sort of token, with a message
+// saying as much.
+
+func ParseTokens[T, A any](p Parser[A], toks []T) Result[A] {
+	src := newSource("")
+	src.toks = toks
+	src.n = len(toks)
+
+	return run(p, src)
+}
+
+// Note that this copies `bs`: The results (e.g., of `Txt`)
+// may refer to the input, and it had better not change
+// under them.
+func ParseBytes[A any](p Parser[A], bs []byte) Result[A] {
+	return Parse(p, string(bs))
+}
+
+// The token-level counterpart of `OneOf`. (Use `Label` to
+// say what it's looking for in error messages.)
+func Satisfy[T any](p func(T) bool) Parser[T] {
+	return makeParser(
+		func(src *source) M[T] {
+			toks, ok := src.toks.([]T)
+			if !ok {
+				msg := fmt.Sprintf("Satisfy: input is %T, not %T", src.toks, toks)
+				if src.textQ() {
+					msg = fmt.Sprintf("Satisfy: input is text, not %T", toks)
+				}
+
+				return M[T]{
+					func(ix int) Result[T] {
+						src.message(ix, msg)
+
+						return failure[T]{}
+					},
+				}
+			}
+
+			return Bind(
+				getSt(),
+				func(ix int) M[T] {
+					if ix < len(toks) && p(toks[ix]) {
+						return Bind(
+							setSt(ix+1),
+							func(data.Unit) M[T] {
+								return Return(toks[ix])
+							},
+						)
+					}
+
+					src.expected(ix, "")
+
+					return fail[T]()
+				},
+			)
+		},
+	)
+}
+
+// Whether we're parsing text (rather than tokens), for the
+// text-based parsers.
+func (src *source) textQ() bool {
+	return src.toks == nil
+}
@@ -0,0 +1,192 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parse
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kdpross/GoParse/pkg/data"
+)
+
+type tokKind int
+
+const (
+	tokNum tokKind = iota
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokKind
+	txt  string
+	pos  int
+}
+
+// The lexer pass: text to tokens.
+var lexer = func() Parser[[]token] {
+	spaces := Regexp(" *")
+	tok := func(k tokKind, p Parser[string]) Parser[token] {
+		return ProcSpan(
+			SeqLeft(p, spaces),
+			func(s string, sp Span) token {
+				return token{kind: k, txt: s, pos: sp.Start}
+			},
+		)
+	}
+
+	return SeqRight(
+		spaces,
+		SeqLeft(
+			Rep(Alt(
+				tok(tokNum, Regexp("[0-9]+")),
+				Alt(
+					tok(tokOp, Regexp("[-+*]")),
+					Alt(tok(tokLParen, Txt("(")), tok(tokRParen, Txt(")"))),
+				),
+			)),
+			Eof(),
+		),
+	)
+}()
+
+func kindP(k tokKind) Parser[token] {
+	return Label(
+		Satisfy(func(t token) bool { return t.kind == k }),
+		[]string{"a number", "an operator", `"("`, `")"`}[k],
+	)
+}
+
+func opP(op string) Parser[token] {
+	return Label(
+		Satisfy(func(t token) bool { return t.kind == tokOp && t.txt == op }),
+		strconv.Quote(op),
+	)
+}
+
+// The parser pass: tokens to a value. (Left-recursive, for
+// good measure.)
+var tokExpr = func() Parser[int] {
+	var expr, term data.Lazy[Parser[int]]
+	var factor Parser[int]
+
+	bin := func(l Parser[int], op string, r Parser[int], f func(int, int) int) Parser[int] {
+		return Proc(Seq(SeqLeft(l, opP(op)), r), func(p data.Pair[int, int]) int { return f(p.First(), p.Second()) })
+	}
+
+	expr = data.MkLazy(func() Parser[int] {
+		return Alt(
+			bin(Cache(expr), "-", Cache(term), func(x, y int) int { return x - y }),
+			Alt(bin(Cache(expr), "+", Cache(term), func(x, y int) int { return x + y }), Cache(term)),
+		)
+	})
+	term = data.MkLazy(func() Parser[int] {
+		return Alt(bin(Cache(term), "*", factor, func(x, y int) int { return x * y }), factor)
+	})
+	factor = Alt(
+		Proc(kindP(tokNum), func(t token) int {
+			n, _ := strconv.Atoi(t.txt)
+
+			return n
+		}),
+		SeqRight(kindP(tokLParen), SeqLeft(Cache(expr), kindP(tokRParen))),
+	)
+
+	return SeqLeft(Cache(expr), Eof())
+}()
+
+func TestParseTokens(t *testing.T) {
+	for _, c := range []struct {
+		s   string
+		exp int
+	}{
+		{"1", 1},
+		{"10 - 2 - 3", 5},
+		{"2 * (3 + 4) - 1", 13},
+		{"  1+2*3 ", 7},
+	} {
+		lr := Parse(lexer, c.s)
+		require.True(t, lr.SuccessQ())
+
+		toks, _ := lr.GetSuccess()
+
+		r := ParseTokens(tokExpr, toks)
+		require.True(t, r.SuccessQ())
+
+		v, ix := r.GetSuccess()
+		assert.Equal(t, c.exp, v)
+		assert.Equal(t, len(toks), ix)
+	}
+}
+
+func TestParseTokensFailure(t *testing.T) {
+	lr := Parse(lexer, "1 + (2 * 3")
+	require.True(t, lr.SuccessQ())
+
+	toks, _ := lr.GetSuccess()
+
+	r := ParseTokens(tokExpr, toks)
+	require.True(t, r.FailureQ())
+
+	e := r.GetFailure()
+	assert.Equal(t, len(toks), e.Offset)
+	assert.Zero(t, e.Line)
+	assert.Equal(t, `token 6: expected "*", "-", "+", or ")"`, e.Error())
+
+	// Which is just after the last token, in the text.
+	assert.Equal(t, 9, toks[len(toks)-1].pos)
+}
+
+func TestParseTokensOther(t *testing.T) {
+	// Text parsers don't match tokens (not even emptily) ...
+	assert.True(t, ParseTokens(Txt("1"), []string{"1"}).FailureQ())
+	assert.True(t, ParseTokens(Txt(""), []string{"1"}).FailureQ())
+	assert.True(t, ParseTokens(Regexp("x*"), []string{"1"}).FailureQ())
+	assert.True(t, ParseTokens(RegexpRE2("x*"), []string{"1"}).FailureQ())
+	assert.True(t, ParseTokens(Peek(func(string, int) bool { return true }), []string{"1"}).FailureQ())
+	// ... token parsers don't parse text ...
+	e := Parse(kindP(tokNum), "1").GetFailure()
+	assert.Equal(t, []string{"Satisfy: input is text, not []parse.token"}, e.Messages)
+	// ... or the wrong sort of token.
+	e = ParseTokens(kindP(tokNum), []int{1}).GetFailure()
+	assert.Equal(t, []string{"Satisfy: input is []int, not []parse.token"}, e.Messages)
+
+	p := SeqLeft(Rep(Satisfy(func(n int) bool { return n > 0 })), Eof())
+	r := ParseTokens(p, []int{3, 1, 4})
+	require.True(t, r.SuccessQ())
+	ns, _ := r.GetSuccess()
+	assert.Equal(t, []int{3, 1, 4}, ns)
+
+	assert.True(t, ParseTokens(p, []int{3, 0, 4}).FailureQ())
+}
+
+func TestParseBytes(t *testing.T) {
+	bs := []byte("foo")
+
+	r := ParseBytes(Txt("foo"), bs)
+	require.True(t, r.SuccessQ())
+
+	bs[0] = 'g'
+
+	s, _ := r.GetSuccess()
+	assert.Equal(t, "foo", s)
+	assert.True(t, ParseBytes(Txt("foo"), bs).FailureQ())
+}
@@ -17,6 +17,8 @@
 package parse
 
 import (
+	"slices"
+
 	"github.com/kdpross/GoParse/pkg/data"
 )
 
@@ -38,18 +40,63 @@ func SeqLeft[A, B any](p1 Parser[A], p2 Parser[B]) Parser[A] {
 	)
 }
 
+// Run `p`, and then whatever parser `f` makes of its value,
+// e.g., to read as many items as a header says there are.
+// (This is `>>=` for parsers, where `Bind` is for `M`.)
+func Then[A, B any](p Parser[A], f func(A) Parser[B]) Parser[B] {
+	return withFirst(makeParser(
+		func(src *source) M[B] {
+			return Bind(
+				p.core(src),
+				func(v A) M[B] {
+					return f(v).core(src)
+				},
+			)
+		},
+	), p.first)
+}
+
+// `Proc` by its functor-ish name.
+func Map[A, B any](p Parser[A], f func(A) B) Parser[B] {
+	return Proc(p, f)
+}
+
+// Apply the function that `pf` parses to the value that `p`
+// (which follows it) parses.
+func Ap[A, B any](pf Parser[func(A) B], p Parser[A]) Parser[B] {
+	return Proc(
+		Seq(pf, p),
+		func(q data.Pair[func(A) B, A]) B {
+			return q.First()(q.Second())
+		},
+	)
+}
+
 // Tie everything together.
 func Parse[A any](p Parser[A], s string) Result[A] {
-	src := source{
-		str:  s,
-		undo: []func(){},
-	}
+	return run(p, newSource(s))
+}
 
+func run[A any](p Parser[A], src *source) Result[A] {
 	res := p.core(src).f(0)
 
-	for i := len(src.undo) - 1; i >= 0; i-- {
-		src.undo[i]()
+	if res.FailureQ() {
+		res = failure[A]{src.parseError()}
+	}
+
+	if len(src.errs) > 0 {
+		return recovered[A]{res, src.errs}
 	}
 
 	return res
 }
+
+// A result (good or bad) despite errors along the way.
+type recovered[A any] struct {
+	Result[A]
+	errs []ParseError
+}
+
+func (r recovered[A]) GetErrors() []ParseError {
+	return append(slices.Clone(r.errs), r.Result.GetErrors()...)
+}
@@ -0,0 +1,177 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parseext
+
+import (
+	"github.com/kdpross/GoParse/pkg/data"
+	"github.com/kdpross/GoParse/pkg/parse"
+)
+
+// Operator-precedence parsing, after Parsec's
+// `buildExpressionParser`: Rather than writing out a rule
+// per precedence level (each referring to the next), give
+// `Expression` the operators, level by level, and it'll do
+// the rest (without needing any left recursion).
+
+type fixity int
+
+const (
+	prefix fixity = iota
+	postfix
+	infixL
+	infixR
+	infixN
+)
+
+// An operator parser, which produces the function that the
+// operator denotes; make these with `Prefix`, `Postfix`,
+// `InfixL`, `InfixR`, and `InfixN`.
+type Operator[A any] struct {
+	fixity fixity
+	un     parse.Parser[func(A) A]
+	bin    parse.Parser[func(A, A) A]
+}
+
+func Prefix[A any](op parse.Parser[func(A) A]) Operator[A] {
+	return Operator[A]{fixity: prefix, un: op}
+}
+
+func Postfix[A any](op parse.Parser[func(A) A]) Operator[A] {
+	return Operator[A]{fixity: postfix, un: op}
+}
+
+// Left-associative: `a - b - c` is `(a - b) - c`.
+func InfixL[A any](op parse.Parser[func(A, A) A]) Operator[A] {
+	return Operator[A]{fixity: infixL, bin: op}
+}
+
+// Right-associative: `a ^ b ^ c` is `a ^ (b ^ c)`.
+func InfixR[A any](op parse.Parser[func(A, A) A]) Operator[A] {
+	return Operator[A]{fixity: infixR, bin: op}
+}
+
+// Non-associative: `a == b == c` is an error.
+func InfixN[A any](op parse.Parser[func(A, A) A]) Operator[A] {
+	return Operator[A]{fixity: infixN, bin: op}
+}
+
+// `table` lists the levels from the tightest-binding to the
+// loosest; within a level, prefix operators bind tighter than
+// postfix ones, and either may be repeated (e.g., `- - x`).
+// Infix operators of differing associativity can't be mixed
+// at one level without brackets (whichever comes first
+// wins).
+func Expression[A any](term parse.Parser[A], table [][]Operator[A]) parse.Parser[A] {
+	res := term
+
+	for _, ops := range table {
+		res = level(res, ops)
+	}
+
+	return res
+}
+
+func level[A any](term parse.Parser[A], ops []Operator[A]) parse.Parser[A] {
+	var pre, post []parse.Parser[func(A) A]
+
+	var l, r, n []parse.Parser[func(A, A) A]
+
+	for _, op := range ops {
+		switch op.fixity {
+		case prefix:
+			pre = append(pre, op.un)
+		case postfix:
+			post = append(post, op.un)
+		case infixL:
+			l = append(l, op.bin)
+		case infixR:
+			r = append(r, op.bin)
+		case infixN:
+			n = append(n, op.bin)
+		}
+	}
+
+	operand := term
+
+	if len(pre) > 0 || len(post) > 0 {
+		operand = parse.Proc(
+			parse.Seq(parse.Rep(parse.Choice(pre...)), parse.Seq(term, parse.Rep(parse.Choice(post...)))),
+			func(p data.Pair[[]func(A) A, data.Pair[A, []func(A) A]]) A {
+				x := p.Second().First()
+
+				for i := len(p.First()) - 1; i >= 0; i-- {
+					x = p.First()[i](x)
+				}
+
+				for _, f := range p.Second().Second() {
+					x = f(x)
+				}
+
+				return x
+			},
+		)
+	}
+
+	// What may follow the first operand, as a function of it.
+	var tails []parse.Parser[func(A) A]
+
+	// (The folds are `ChainR1`'s and `ChainL1`'s; the first
+	// operand is shared between the tails, though.)
+	if len(r) > 0 {
+		tails = append(tails, parse.Proc(
+			Rep1(parse.Seq(parse.Choice(r...), operand)),
+			func(ps []data.Pair[func(A, A) A, A]) func(A) A {
+				return func(x A) A {
+					return foldR(x, ps)
+				}
+			},
+		))
+	}
+
+	if len(l) > 0 {
+		tails = append(tails, parse.Proc(
+			Rep1(parse.Seq(parse.Choice(l...), operand)),
+			func(ps []data.Pair[func(A, A) A, A]) func(A) A {
+				return func(x A) A {
+					return foldL(x, ps)
+				}
+			},
+		))
+	}
+
+	if len(n) > 0 {
+		tails = append(tails, parse.Proc(
+			parse.SeqLeft(parse.Seq(parse.Choice(n...), operand), parse.NotFollowedBy(parse.Choice(n...))),
+			func(p data.Pair[func(A, A) A, A]) func(A) A {
+				return func(x A) A {
+					return p.First()(x, p.Second())
+				}
+			},
+		))
+	}
+
+	if len(tails) == 0 {
+		return operand
+	}
+
+	return parse.Proc(
+		parse.Seq(operand, parse.Alt(parse.Choice(tails...), parse.ParserJust(func(x A) A { return x }))),
+		func(p data.Pair[A, func(A) A]) A {
+			return p.Second()(p.First())
+		},
+	)
+}
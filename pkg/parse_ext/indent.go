@@ -0,0 +1,67 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parseext
+
+import (
+	"fmt"
+
+	"github.com/kdpross/GoParse/pkg/data"
+	"github.com/kdpross/GoParse/pkg/parse"
+)
+
+// The offside rule (à la Haskell's `indents`): A block is a
+// run of items that all start in the same column, which
+// becomes the reference column (see `parse.Column` and
+// friends) for whatever's inside them; a nested block (or
+// continuation line) goes farther right. Note that each item
+// is expected to skip whatever follows it (e.g., up to the
+// start of the next line), as that's where the next item's
+// column is measured.
+
+// `p`, provided that it starts to the right of the
+// reference column.
+func Indented[A any](p parse.Parser[A]) parse.Parser[A] {
+	return checkIndent(p, "greater than", func(col, ref int) bool { return col > ref })
+}
+
+// `p`, provided that it starts in the reference column.
+func SameColumn[A any](p parse.Parser[A]) parse.Parser[A] {
+	return checkIndent(p, "equal to", func(col, ref int) bool { return col == ref })
+}
+
+// One or more `p`s, all starting in the column that the first
+// one does, which is the reference column meanwhile.
+func Block[A any](p parse.Parser[A]) parse.Parser[[]A] {
+	return parse.Then(parse.Column(), func(col int) parse.Parser[[]A] {
+		return parse.WithReferenceIndent(col, Rep1(SameColumn(p)))
+	})
+}
+
+func checkIndent[A any](p parse.Parser[A], what string, ok func(col, ref int) bool) parse.Parser[A] {
+	return parse.Then(
+		parse.Seq(parse.Column(), parse.ReferenceIndent()),
+		func(cr data.Pair[int, int]) parse.Parser[A] {
+			if !ok(cr.First(), cr.Second()) {
+				return parse.ParserFail[A](
+					fmt.Sprintf("incorrect indentation (got %d, should be %s %d)", cr.First(), what, cr.Second()),
+				)
+			}
+
+			return p
+		},
+	)
+}
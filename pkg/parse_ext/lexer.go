@@ -0,0 +1,164 @@
+// ┌─────────────────────────────────────────────────────────────┐
+// │ GoParse: A Golang parser-combinator library.                │
+// │                                                             │
+// │ This codebase is licensed for the following purposes only:  │
+// │                                                             │
+// │ - study of the code                                         │
+// │                                                             │
+// │ - compiling / running an unaltered copy of the code for     │
+// │   noncommercial educational and entertainment purposes only │
+// │                                                             │
+// │ - gratis redistribution of the code in entirety and in      │
+// │   unaltered form for any aforementioned purpose             │
+// │                                                             │
+// │ Copyright 2022-2025, K.D.P.Ross                             │
+// └─────────────────────────────────────────────────────────────┘
+
+package parseext
+
+import (
+	"github.com/kdpross/GoParse/pkg/data"
+	"github.com/kdpross/GoParse/pkg/parse"
+)
+
+// The usual way of dealing with whitespace (and comments): Each
+// token skips whatever 'trivia' follows it, so the grammar
+// proper needn't mention any. (Skip any at the very start
+// with `Trivia`.) The zero `Lexer` has ASCII whitespace, no
+// comments, and C-style identifiers. Make one with `MkLexer`,
+// so that the tokens can share the one trivia parser (and
+// its memo table).
+type Lexer struct {
+	// Whitespace; nil means ' ', '\t', '\n', and '\r'.
+	Space func(byte) bool
+	// Line comments run from `LineComment` to the end of the
+	// line, and block comments from `BlockStart` to
+	// `BlockEnd` (and may be nested); "" means that there
+	// aren't any.
+	LineComment          string
+	BlockStart, BlockEnd string
+	// What identifiers (and keywords) are made of; nil means
+	// letters, digits, and '_', but not starting with a
+	// digit.
+	IdentStart, IdentRest func(byte) bool
+
+	trivia *parse.Parser[data.Unit]
+}
+
+// `l`, with its trivia parser built (once and for all).
+func MkLexer(l Lexer) Lexer {
+	t := l.mkTrivia()
+	l.trivia = &t
+
+	return l
+}
+
+func whitespaceQ(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+func identStartQ(b byte) bool {
+	return b == '_' || UpperC(b) || LowerC(b)
+}
+
+func identRestQ(b byte) bool {
+	return identStartQ(b) || DigitC(b)
+}
+
+func (l Lexer) space() func(byte) bool {
+	if l.Space == nil {
+		return whitespaceQ
+	}
+
+	return l.Space
+}
+
+func (l Lexer) identStart() func(byte) bool {
+	if l.IdentStart == nil {
+		return identStartQ
+	}
+
+	return l.IdentStart
+}
+
+func (l Lexer) identRest() func(byte) bool {
+	if l.IdentRest == nil {
+		return identRestQ
+	}
+
+	return l.IdentRest
+}
+
+// Any amount of whitespace and comments. This doesn't show up
+// in error messages (which would otherwise suggest a space
+// after every token), except for an unterminated block
+// comment.
+func (l Lexer) Trivia() parse.Parser[data.Unit] {
+	// (A `Lexer` that didn't come from `MkLexer` has to make
+	// do with a new one each time.)
+	if l.trivia != nil {
+		return *l.trivia
+	}
+
+	return l.mkTrivia()
+}
+
+func (l Lexer) mkTrivia() parse.Parser[data.Unit] {
+	// Each repetition is labelled with "" (which is what keeps
+	// trivia out of error messages), so they're a character at
+	// a time.
+	alts := []parse.Parser[data.Unit]{skip(parse.OneOf(l.space()))}
+
+	if l.LineComment != "" {
+		alts = append(alts, skip(parse.Seq(
+			parse.Txt(l.LineComment),
+			parse.Rep(parse.Label(parse.NoneOf(OneOfC("\n")), "")),
+		)))
+	}
+
+	if l.BlockStart != "" {
+		var block data.Lazy[parse.Parser[data.Unit]]
+		block = data.MkLazy(func() parse.Parser[data.Unit] {
+			other := parse.SeqRight(
+				parse.NotFollowedBy(parse.Alt(parse.Txt(l.BlockStart), parse.Txt(l.BlockEnd))),
+				parse.OneOf(func(byte) bool { return true }),
+			)
+
+			return skip(parse.Seq3(
+				parse.Txt(l.BlockStart),
+				parse.Rep(parse.Label(parse.Alt(parse.Cache(block), skip(other)), "")),
+				parse.Txt(l.BlockEnd),
+			))
+		})
+
+		alts = append(alts, parse.Cache(block))
+	}
+
+	return skip(parse.Rep(parse.Label(parse.Choice(alts...), "")))
+}
+
+func skip[A any](p parse.Parser[A]) parse.Parser[data.Unit] {
+	return parse.Proc(p, func(A) data.Unit { return data.Unit{} })
+}
+
+// `p`, and then any trivia. (This would be a method, but Go
+// won't have generic ones.)
+func Lexeme[A any](l Lexer, p parse.Parser[A]) parse.Parser[A] {
+	return parse.SeqLeft(p, l.Trivia())
+}
+
+// Exactly `s` (see also `Keyword`).
+func (l Lexer) Symbol(s string) parse.Parser[string] {
+	return Lexeme(l, parse.Txt(s))
+}
+
+// `s`, but not as the start of a longer identifier (so
+// `Keyword("let")` doesn't match `letter`).
+func (l Lexer) Keyword(s string) parse.Parser[string] {
+	return Lexeme(l, KeywordOf(s, l.identRest()))
+}
+
+// An identifier, other than any of the `reserved` words.
+func (l Lexer) Identifier(reserved ...string) parse.Parser[string] {
+	return Lexeme(l, parse.Label(IdentOfExcept(l.identStart(), l.identRest(), reserved...), "an identifier"))
+}
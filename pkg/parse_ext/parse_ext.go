@@ -17,6 +17,10 @@
 package parseext
 
 import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/kdpross/GoParse/pkg/data"
 	"github.com/kdpross/GoParse/pkg/parse"
 )
@@ -37,22 +41,92 @@ func RepSep[A, B any](p parse.Parser[A], s parse.Parser[B]) parse.Parser[[]A] {
 	return parse.Alt(RepSep1(p, s), parse.ParserJust([]A{}))
 }
 
+// One or more `p`s separated by `op`s, which are combined
+// left-associatively: `a - b - c` is `(a - b) - c`. (This is
+// Parsec's `chainl1`.)
+func ChainL1[A any](p parse.Parser[A], op parse.Parser[func(A, A) A]) parse.Parser[A] {
+	return parse.Proc(
+		parse.Seq(p, parse.Rep(parse.Seq(op, p))),
+		func(q data.Pair[A, []data.Pair[func(A, A) A, A]]) A {
+			return foldL(q.First(), q.Second())
+		},
+	)
+}
+
+// As `ChainL1`, but right-associatively: `a ^ b ^ c` is
+// `a ^ (b ^ c)`. (This is Parsec's `chainr1`.)
+func ChainR1[A any](p parse.Parser[A], op parse.Parser[func(A, A) A]) parse.Parser[A] {
+	return parse.Proc(
+		parse.Seq(p, parse.Rep(parse.Seq(op, p))),
+		func(q data.Pair[A, []data.Pair[func(A, A) A, A]]) A {
+			return foldR(q.First(), q.Second())
+		},
+	)
+}
+
+// `x`, followed by the operators and operands `rs`, combined
+// left-associatively ...
+func foldL[A any](x A, rs []data.Pair[func(A, A) A, A]) A {
+	for _, r := range rs {
+		x = r.First()(x, r.Second())
+	}
+
+	return x
+}
+
+// ... and right-associatively.
+func foldR[A any](x A, rs []data.Pair[func(A, A) A, A]) A {
+	if len(rs) == 0 {
+		return x
+	}
+
+	y := rs[len(rs)-1].Second()
+
+	for i := len(rs) - 1; i > 0; i-- {
+		y = rs[i].First()(rs[i-1].Second(), y)
+	}
+
+	return rs[0].First()(x, y)
+}
+
 // This seems to come up as a common pattern: Identifiers,
 // etc. have some rule for the first character and different
 // rules for subsequent ones.
 func IdentOf(fst, rst func(byte) bool) parse.Parser[string] {
-	return parse.Proc(
-		parse.Seq(parse.OneOf(fst), parse.Rep(parse.OneOf(rst))),
-		func(p data.Pair[byte, []byte]) string {
-			return string(append([]byte{p.First()}, p.Second()...))
-		},
-	)
+	return parse.Recognize(parse.Seq(parse.OneOf(fst), parse.Rep(parse.OneOf(rst))))
 }
 
 func StringOf(p func(byte) bool) parse.Parser[string] {
 	return IdentOf(p, p)
 }
 
+// `IdentOf`, but not any of the `reserved` words (which is an
+// error about the word, reported where it starts).
+func IdentOfExcept(fst, rst func(byte) bool, reserved ...string) parse.Parser[string] {
+	isReserved := map[string]bool{}
+
+	for _, s := range reserved {
+		isReserved[s] = true
+	}
+
+	// Look before we leap, so that an error is where the word
+	// is (and doesn't mention what might have continued it).
+	return parse.Then(parse.LookAhead(IdentOf(fst, rst)), func(s string) parse.Parser[string] {
+		if isReserved[s] {
+			return parse.ParserFail[string](fmt.Sprintf("keyword `%s` cannot be used as identifier", s))
+		}
+
+		return parse.Txt(s)
+	})
+}
+
+// The keyword `kw`, provided that it isn't just the start of
+// a longer word (of `rst` characters), so `KeywordOf("let",
+// ...)` doesn't match `letter`.
+func KeywordOf(kw string, rst func(byte) bool) parse.Parser[string] {
+	return parse.SeqLeft(parse.Txt(kw), parse.EowOf(rst))
+}
+
 func spaceQ(b byte) bool {
 	return b == ' '
 }
@@ -115,11 +189,16 @@ func RangeC(l, h byte) func(byte) bool {
 	}
 }
 
+// Only for ASCII characters: Anything else doesn't fit in a
+// byte, so it never matches (rather than some other
+// character matching in its place); use `CharsR` instead.
 func OneOfC(s string) func(byte) bool {
 	m := map[byte]bool{}
 
 	for _, c := range s {
-		m[byte(c)] = true
+		if c < utf8.RuneSelf {
+			m[byte(c)] = true
+		}
 	}
 
 	return func(c byte) bool {
@@ -135,3 +214,46 @@ var UpperC = RangeC('A', 'Z')
 var LowerC = RangeC('a', 'z')
 var DigitC = RangeC('0', '9')
 var VarP = StringOf(LowerC)
+
+// Rune-based counterparts of the above, for use with
+// `parse.OneOfR`, etc.
+
+func RangeR(l, h rune) func(rune) bool {
+	return func(c rune) bool {
+		return c >= l && c <= h
+	}
+}
+
+func CharsR(s string) func(rune) bool {
+	m := map[rune]bool{}
+
+	for _, c := range s {
+		m[c] = true
+	}
+
+	return func(c rune) bool {
+		return m[c]
+	}
+}
+
+// Any of the Unicode categories / scripts, e.g.,
+// `CategoryR(unicode.Greek, unicode.Sm)`.
+func CategoryR(ts ...*unicode.RangeTable) func(rune) bool {
+	return func(c rune) bool {
+		return unicode.In(c, ts...)
+	}
+}
+
+func IdentOfR(fst, rst func(rune) bool) parse.Parser[string] {
+	return parse.Recognize(parse.Seq(parse.OneOfR(fst), parse.Rep(parse.OneOfR(rst))))
+}
+
+func StringOfR(p func(rune) bool) parse.Parser[string] {
+	return IdentOfR(p, p)
+}
+
+var UpperR = unicode.IsUpper
+var LowerR = unicode.IsLower
+var LetterR = unicode.IsLetter
+var DigitR = unicode.IsDigit
+var SpaceR = unicode.IsSpace
@@ -20,10 +20,12 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"unicode"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/kdpross/GoParse/pkg/data"
 	"github.com/kdpross/GoParse/pkg/parse"
 )
 
@@ -135,6 +137,65 @@ func TestIdentOf(t *testing.T) {
 	}
 }
 
+func TestIdentOfExcept(t *testing.T) {
+	p := parse.SeqLeft(IdentOfExcept(LowerC, LowerC, "if", "let"), parse.Eof())
+
+	for _, s := range []string{"x", "i", "iff", "lets", "letter"} {
+		r := parse.Parse(p, s)
+
+		require.True(t, r.SuccessQ(), s)
+
+		v, _ := r.GetSuccess()
+		assert.Equal(t, s, v)
+	}
+
+	for _, s := range []string{"if", "let"} {
+		r := parse.Parse(p, s)
+
+		require.True(t, r.FailureQ(), s)
+
+		e := r.GetFailure()
+		assert.Equal(t, 0, e.Offset)
+		assert.Equal(t, []string{"keyword `" + s + "` cannot be used as identifier"}, e.Messages)
+		assert.Empty(t, e.Expected)
+	}
+}
+
+func TestKeywordOf(t *testing.T) {
+	p := parse.SeqLeft(KeywordOf("let", LowerC), parse.Eof())
+
+	assert.True(t, parse.Parse(p, "let").SuccessQ())
+
+	// Nor are identifiers keywords.
+	for _, s := range []string{"letter", "le", "lets"} {
+		assert.True(t, parse.Parse(p, s).FailureQ(), s)
+	}
+
+	// (It's only words of `rst` that count.)
+	assert.True(t, parse.Parse(parse.SeqLeft(KeywordOf("let", LowerC), parse.Txt("1")), "let1").SuccessQ())
+
+	// Keywords and identifiers don't mix, either way round.
+	kwOrIdent := parse.SeqLeft(
+		parse.Alt(
+			parse.Proc(KeywordOf("let", LowerC), func(string) string { return "keyword" }),
+			parse.Proc(IdentOfExcept(LowerC, LowerC, "let"), func(string) string { return "identifier" }),
+		),
+		parse.Eof(),
+	)
+
+	for _, c := range []struct{ s, exp string }{
+		{"let", "keyword"},
+		{"letter", "identifier"},
+	} {
+		r := parse.Parse(kwOrIdent, c.s)
+
+		require.True(t, r.SuccessQ(), c.s)
+
+		v, _ := r.GetSuccess()
+		assert.Equal(t, c.exp, v, c.s)
+	}
+}
+
 func TestStringOf(t *testing.T) {
 	p := StringOf(DigitC)
 
@@ -255,3 +316,316 @@ func TestBrackets(t *testing.T) {
 
 	assert.True(t, parse.Parse(p, "(foo())").FailureQ())
 }
+
+func TestOneOfC(t *testing.T) {
+	p := OneOfC("+-*/")
+
+	assert.True(t, p('+'))
+	assert.False(t, p('x'))
+	// Non-ASCII characters don't match at all, rather than
+	// being cut down to a byte (0x92, for `→`).
+	q := OneOfC("+→")
+
+	assert.True(t, q('+'))
+
+	for i := 0; i < 256; i++ {
+		assert.Equal(t, i == '+', q(byte(i)), "byte %#x", i)
+	}
+
+	assert.True(t, parse.Parse(parse.OneOf(q), "\x92").FailureQ())
+}
+
+func TestRangeRAndCharsR(t *testing.T) {
+	greek := RangeR('α', 'ω')
+	ops := CharsR("→∀+")
+
+	assert.True(t, greek('λ'))
+	assert.False(t, greek('a'))
+	assert.True(t, ops('→'))
+	assert.True(t, ops('+'))
+	assert.False(t, ops('-'))
+	assert.True(t, CategoryR(unicode.Greek, unicode.Sm)('∀'))
+	assert.False(t, CategoryR(unicode.Greek)('∀'))
+}
+
+func TestIdentOfR(t *testing.T) {
+	p := IdentOfR(LetterR, func(c rune) bool { return LetterR(c) || DigitR(c) || c == '_' })
+
+	for _, c := range []struct {
+		s, exp string
+	}{
+		{"café", "café"},
+		{"naïve_2 rest", "naïve_2"},
+		{"λx→y", "λx"},
+		{"Ærøskøbing", "Ærøskøbing"},
+		{"2abc", ""},
+		{"", ""},
+	} {
+		r := parse.Parse(p, c.s)
+
+		if c.exp == "" {
+			assert.True(t, r.FailureQ())
+		} else {
+			require.True(t, r.SuccessQ())
+
+			s, ix := r.GetSuccess()
+
+			assert.Equal(t, c.exp, s)
+			assert.Equal(t, len(c.exp), ix)
+		}
+	}
+}
+
+func TestStringOfR(t *testing.T) {
+	p := parse.SeqLeft(StringOfR(CharsR("→←↔")), parse.Eof())
+
+	r := parse.Parse(p, "→←↔→")
+	require.True(t, r.SuccessQ())
+	s, _ := r.GetSuccess()
+	assert.Equal(t, "→←↔→", s)
+
+	assert.True(t, parse.Parse(p, "→x").FailureQ())
+}
+
+func TestExpression(t *testing.T) {
+	bin := func(op string) parse.Parser[func(string, string) string] {
+		return parse.Proc(parse.Txt(op), func(string) func(string, string) string {
+			return func(x, y string) string { return "(" + x + " " + op + " " + y + ")" }
+		})
+	}
+	un := func(op string, f func(string) string) parse.Parser[func(string) string] {
+		return parse.Proc(parse.Txt(op), func(string) func(string) string { return f })
+	}
+
+	var expr data.Lazy[parse.Parser[string]]
+	expr = data.MkLazy(func() parse.Parser[string] {
+		return Expression(
+			parse.Alt(StringOf(DigitC), Brackets(parse.Cache(expr))),
+			[][]Operator[string]{
+				{
+					Prefix(un("-", func(x string) string { return "(-" + x + ")" })),
+					Postfix(un("!", func(x string) string { return "(" + x + "!)" })),
+				},
+				{InfixR(bin("^"))},
+				{InfixL(bin("*")), InfixL(bin("/"))},
+				{InfixL(bin("+")), InfixL(bin("-"))},
+				{InfixN(bin("=="))},
+			},
+		)
+	})
+	p := parse.SeqLeft(parse.Cache(expr), parse.Eof())
+
+	for _, c := range []struct{ s, exp string }{
+		{"1", "1"},
+		{"1+2*3", "(1 + (2 * 3))"},
+		{"1-2-3", "((1 - 2) - 3)"},
+		{"1-(2-3)", "(1 - (2 - 3))"},
+		{"8/4*2", "((8 / 4) * 2)"},
+		{"2^3^4", "(2 ^ (3 ^ 4))"},
+		{"2*3^4", "(2 * (3 ^ 4))"},
+		{"-1!", "((-1)!)"},
+		{"--1", "(-(-1))"},
+		{"1--1", "(1 - (-1))"},
+		{"1+2==3", "((1 + 2) == 3)"},
+	} {
+		r := parse.Parse(p, c.s)
+
+		require.True(t, r.SuccessQ(), c.s)
+
+		v, _ := r.GetSuccess()
+		assert.Equal(t, c.exp, v, c.s)
+	}
+
+	r := parse.Parse(p, "1==2==3")
+
+	require.True(t, r.FailureQ())
+
+	e := r.GetFailure()
+	assert.Equal(t, 4, e.Offset)
+	assert.Equal(t, []string{`unexpected "=="`}, e.Messages)
+}
+
+func TestChainL1AndChainR1(t *testing.T) {
+	op := parse.Proc(parse.Txt("-"), func(string) func(string, string) string {
+		return func(x, y string) string { return "(" + x + "-" + y + ")" }
+	})
+
+	for _, c := range []struct {
+		lab string
+		p   parse.Parser[string]
+		s   string
+		exp string
+	}{
+		{"left single", ChainL1(StringOf(DigitC), op), "1", "1"},
+		{"left", ChainL1(StringOf(DigitC), op), "1-2-3", "((1-2)-3)"},
+		{"left trailing", ChainL1(StringOf(DigitC), op), "1-2-", "(1-2)"},
+		{"right single", ChainR1(StringOf(DigitC), op), "1", "1"},
+		{"right", ChainR1(StringOf(DigitC), op), "1-2-3", "(1-(2-3))"},
+		{"right trailing", ChainR1(StringOf(DigitC), op), "1-2-", "(1-2)"},
+	} {
+		t.Run(c.lab, func(t *testing.T) {
+			r := parse.Parse(c.p, c.s)
+
+			require.True(t, r.SuccessQ())
+
+			v, _ := r.GetSuccess()
+			assert.Equal(t, c.exp, v)
+		})
+	}
+
+	assert.True(t, parse.Parse(ChainL1(StringOf(DigitC), op), "-1").FailureQ())
+	assert.True(t, parse.Parse(ChainR1(StringOf(DigitC), op), "").FailureQ())
+}
+
+func TestBlock(t *testing.T) {
+	lineEnd := parse.Rep(parse.Seq(parse.Txt("\n"), Spaces))
+
+	var node data.Lazy[parse.Parser[string]]
+	node = data.MkLazy(func() parse.Parser[string] {
+		return parse.Proc(
+			parse.Seq(parse.SeqLeft(StringOf(RangeC('a', 'z')), lineEnd), Maybe(Indented(Block(parse.Cache(node))))),
+			func(p data.Pair[string, data.Maybe[[]string]]) string {
+				if p.Second().NothingQ() {
+					return p.First()
+				}
+
+				return p.First() + "[" + strings.Join(p.Second().GetJust(), ",") + "]"
+			},
+		)
+	})
+
+	p := parse.SeqLeft(Block(parse.Cache(node)), parse.Eof())
+
+	for _, c := range []struct {
+		s   string
+		exp []string
+	}{
+		{"a", []string{"a"}},
+		{"a\nb\n", []string{"a", "b"}},
+		{"a\n  b\n  c\n    d\ne\n", []string{"a[b,c[d]]", "e"}},
+		{"a\n  b\n\n  c\n", []string{"a[b,c]"}},
+		{"a\n b\n  c\n d\n", []string{"a[b[c],d]"}},
+	} {
+		r := parse.Parse(p, c.s)
+
+		require.True(t, r.SuccessQ(), c.s)
+
+		v, _ := r.GetSuccess()
+		assert.Equal(t, c.exp, v, c.s)
+	}
+
+	r := parse.Parse(p, "a\n  b\n c\n")
+
+	require.True(t, r.FailureQ())
+
+	e := r.GetFailure()
+	assert.Equal(t, 7, e.Offset)
+	assert.Contains(t, e.Messages, "incorrect indentation (got 2, should be equal to 3)")
+	assert.Contains(t, e.Messages, "incorrect indentation (got 2, should be equal to 1)")
+
+	// Nothing can be indented at the top level.
+	assert.True(t, parse.Parse(parse.SeqRight(Spaces, Block(parse.Cache(node))), "  a").SuccessQ())
+	assert.True(t, parse.Parse(parse.SeqRight(Spaces, SameColumn(parse.Cache(node))), "  a").FailureQ())
+}
+
+// The same rule, at the same place, but with different
+// reference columns.
+func TestIndentedCache(t *testing.T) {
+	var x data.Lazy[parse.Parser[string]]
+	x = data.MkLazy(func() parse.Parser[string] {
+		return Indented(parse.Txt("x"))
+	})
+
+	p := parse.SeqRight(Spaces, parse.Alt(
+		parse.WithReferenceIndent(5, parse.SeqLeft(parse.Cache(x), parse.Txt("!"))),
+		parse.WithReferenceIndent(1, parse.Cache(x)),
+	))
+	p = parse.SeqLeft(p, parse.Eof())
+
+	assert.True(t, parse.Parse(p, "  x").SuccessQ())
+	assert.True(t, parse.Parse(p, "      x!").SuccessQ())
+	assert.True(t, parse.Parse(p, "  x!").FailureQ())
+}
+
+func TestLexer(t *testing.T) {
+	l := MkLexer(Lexer{LineComment: "//", BlockStart: "/*", BlockEnd: "*/"})
+
+	// let <ident> = <ident> ;
+	let := parse.SeqRight(
+		l.Trivia(),
+		parse.Proc4(
+			l.Keyword("let"),
+			l.Identifier("let"),
+			l.Symbol("="),
+			parse.SeqLeft(l.Identifier("let"), l.Symbol(";")),
+			func(_, x, _, y string) data.Pair[string, string] { return data.MkPair(x, y) },
+		),
+	)
+	p := parse.SeqLeft(let, parse.Eof())
+
+	for _, s := range []string{
+		"let x = y;",
+		"let x=y;",
+		" \n let\tx =\r\ny ;\n",
+		"let x = // comment\n y; // another",
+		"let /* a /* nested */ comment */ x = y;",
+		"/**/let x/* */=y;",
+	} {
+		r := parse.Parse(p, s)
+
+		require.True(t, r.SuccessQ(), s)
+
+		v, _ := r.GetSuccess()
+		assert.Equal(t, data.MkPair("x", "y"), v, s)
+	}
+
+	for _, c := range []struct {
+		s      string
+		offset int
+		exp    []string
+	}{
+		// `let` has to be a whole word.
+		{"letx = y;", 3, []string{"end of word"}},
+		{"let x = ;", 8, []string{"an identifier"}},
+		{"let x = y", 9, []string{`";"`}},
+		{"let x = y; /* oops", 18, []string{`"*/"`}},
+	} {
+		r := parse.Parse(p, c.s)
+
+		require.True(t, r.FailureQ(), c.s)
+
+		e := r.GetFailure()
+		assert.Equal(t, c.offset, e.Offset, c.s)
+		assert.Equal(t, c.exp, e.Expected, c.s)
+	}
+
+	r := parse.Parse(p, "let let = y;")
+
+	require.True(t, r.FailureQ())
+
+	e := r.GetFailure()
+	assert.Equal(t, 4, e.Offset)
+	assert.Equal(t, []string{"keyword `let` cannot be used as identifier"}, e.Messages)
+	assert.Equal(t, "line 1, column 5: keyword `let` cannot be used as identifier; expected an identifier", e.Error())
+}
+
+func TestLexerCustom(t *testing.T) {
+	l := MkLexer(Lexer{Space: OneOfC(" "), LineComment: "#", IdentStart: LowerC, IdentRest: OneOfC("abcdefghijklmnopqrstuvwxyz-")})
+
+	p := parse.SeqLeft(Rep1(l.Identifier()), parse.Eof())
+
+	r := parse.Parse(p, "foo-bar baz # qux")
+
+	require.True(t, r.SuccessQ())
+
+	v, _ := r.GetSuccess()
+	assert.Equal(t, []string{"foo-bar", "baz"}, v)
+
+	assert.True(t, parse.Parse(p, "foo\tbar").FailureQ())
+
+	// The tokens share `MkLexer`'s trivia, but a `Lexer` that
+	// didn't come from it works all the same.
+	require.NotNil(t, l.trivia)
+	assert.Nil(t, Lexer{}.trivia)
+	assert.True(t, parse.Parse(parse.SeqLeft(Lexer{}.Symbol("x"), parse.Eof()), "x \n").SuccessQ())
+}